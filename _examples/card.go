@@ -4,21 +4,15 @@ import (
 	"context"
 	"fmt"
 	"github.com/swerv-ltd/swervpay-go"
-	"os"
 )
 
 func testCards() {
 	ctx := context.Background()
 
-	businessId := os.Getenv("SWERVPAY_BUSINESS_ID")
-	secretKey := os.Getenv("SWERVPAY_SECRET_KEY")
-	baseUrl := os.Getenv("SWERVPAY_BASE_URL")
-
-	client := swervpay.NewSwervpayClient(&swervpay.SwervpayClientOption{
-		BusinessID: businessId,
-		SecretKey:  secretKey,
-		BaseURL:    baseUrl,
-	})
+	client, err := swervpay.NewSwervpayClientFromEnv()
+	if err != nil {
+		panic(err)
+	}
 
 	// Get all cards
 	cards, err := client.Card.Gets(ctx, &swervpay.PageAndLimitQuery{
@@ -4,22 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/swerv-ltd/swervpay-go"
-	"os"
 )
 
 func testCollection() {
 
 	ctx := context.Background()
 
-	businessId := os.Getenv("SWERVPAY_BUSINESS_ID")
-	secretKey := os.Getenv("SWERVPAY_SECRET_KEY")
-	baseUrl := os.Getenv("SWERVPAY_BASE_URL")
-
-	client := swervpay.NewSwervpayClient(&swervpay.SwervpayClientOption{
-		BusinessID: businessId,
-		SecretKey:  secretKey,
-		BaseURL:    baseUrl,
-	})
+	client, err := swervpay.NewSwervpayClientFromEnv()
+	if err != nil {
+		panic(err)
+	}
 
 	// Get all collections
 	collections, err := client.Collection.Gets(ctx, &swervpay.PageAndLimitQuery{
@@ -48,7 +42,7 @@ func testCollection() {
 	newCollection, err := client.Collection.Create(ctx, &swervpay.CreateCollectionBody{
 		Amount:       1000,
 		Currency:     "NGN",
-		Type:         "ONE_TIME",
+		Type:         swervpay.CollectionTypeOneTime,
 		MerchantName: "John Doe",
 	})
 
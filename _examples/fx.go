@@ -4,22 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/swerv-ltd/swervpay-go"
-	"os"
 )
 
 func testFx() {
 
 	ctx := context.Background()
 
-	businessId := os.Getenv("SWERVPAY_BUSINESS_ID")
-	secretKey := os.Getenv("SWERVPAY_SECRET_KEY")
-	baseUrl := os.Getenv("SWERVPAY_BASE_URL")
-
-	client := swervpay.NewSwervpayClient(&swervpay.SwervpayClientOption{
-		BusinessID: businessId,
-		SecretKey:  secretKey,
-		BaseURL:    baseUrl,
-	})
+	client, err := swervpay.NewSwervpayClientFromEnv()
+	if err != nil {
+		panic(err)
+	}
 
 	exchange, err := client.Fx.Exchange(ctx, swervpay.FxBody{
 		From:   "USD",
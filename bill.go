@@ -19,12 +19,22 @@ type BillerList struct {
 
 // BillerItem represents a billable item for a biller.
 type BillerItem struct {
-	Amount   float64 `json:"amount"`   // Item amount.
-	Code     string  `json:"code"`     // Item code.
-	Currency string  `json:"currency"` // Currency for the item.
-	Fee      float64 `json:"fee"`      // Associated fee.
-	ID       string  `json:"id"`       // Item identifier.
-	Name     string  `json:"name"`     // Item name.
+	Amount   float64  `json:"amount"`   // Item amount.
+	Code     string   `json:"code"`     // Item code.
+	Currency Currency `json:"currency"` // Currency for the item.
+	Fee      float64  `json:"fee"`      // Associated fee.
+	ID       string   `json:"id"`       // Item identifier.
+	Name     string   `json:"name"`     // Item name.
+}
+
+// AmountMoney returns Amount in the item's currency.
+func (b BillerItem) AmountMoney() Money {
+	return NewMoney(AmountFromFloat(b.Amount), b.Currency)
+}
+
+// FeeMoney returns Fee in the item's currency.
+func (b BillerItem) FeeMoney() Money {
+	return NewMoney(AmountFromFloat(b.Fee), b.Currency)
 }
 
 // CreateBillBody represents the payload to create a bill.
@@ -37,6 +47,17 @@ type CreateBillBody struct {
 	Reference  string  `json:"reference"`   // Reference for idempotency.
 }
 
+// Validate checks the bill before it is sent.
+func (b CreateBillBody) Validate() error {
+	v := newValidation("CreateBillBody")
+	v.positive("amount", b.Amount)
+	v.required("biller_id", b.BillerID)
+	v.required("category", b.Category)
+	v.required("customer_id", b.CustomerID)
+	v.required("item_id", b.ItemID)
+	return v.result()
+}
+
 // ValidateBillBody represents the payload to validate a bill for a customer.
 type ValidateBillBody struct {
 	BillerID   string `json:"biller_id"`   // Biller identifier.
@@ -45,6 +66,16 @@ type ValidateBillBody struct {
 	ItemID     string `json:"item_id"`     // Item identifier.
 }
 
+// Validate checks the bill before it is sent.
+func (b ValidateBillBody) Validate() error {
+	v := newValidation("ValidateBillBody")
+	v.required("biller_id", b.BillerID)
+	v.required("category", b.Category)
+	v.required("customer_id", b.CustomerID)
+	v.required("item_id", b.ItemID)
+	return v.result()
+}
+
 // BillDetail represents the bill details nested in a transaction.
 type BillDetail struct {
 	BillCode string `json:"bill_code"`       // Bill code.
@@ -56,44 +87,46 @@ type BillDetail struct {
 
 // BillTransaction represents a bill transaction.
 type BillTransaction struct {
-	AccountName   string      `json:"account_name"`             // Account holder name.
-	AccountNumber string      `json:"account_number"`           // Account number.
-	Amount        float64     `json:"amount"`                   // Transaction amount.
-	BankCode      string      `json:"bank_code"`                // Bank code.
-	BankName      string      `json:"bank_name"`                // Bank name.
-	Bill          *BillDetail `json:"bill,omitempty"`           // Bill detail payload.
-	Category      string      `json:"category"`                 // Category identifier.
-	Charges       float64     `json:"charges"`                  // Transaction charges.
-	CreatedAt     string      `json:"created_at"`               // Creation timestamp.
-	Detail        string      `json:"detail"`                   // Transaction detail.
-	FiatRate      float64     `json:"fiat_rate"`                // Fiat conversion rate.
-	ID            string      `json:"id"`                       // Transaction ID.
-	Imad          string      `json:"imad,omitempty"`           // IMAD reference.
-	PaymentMethod string      `json:"payment_method,omitempty"` // Payment method.
-	Reference     string      `json:"reference"`                // Reference string.
-	Report        bool        `json:"report"`                   // Report status.
-	ReportMessage string      `json:"report_message,omitempty"` // Report message.
-	SessionID     string      `json:"session_id,omitempty"`     // Session ID.
-	Status        string      `json:"status"`                   // Transaction status.
-	TraceNumber   string      `json:"trace_number,omitempty"`   // Trace number.
-	Type          string      `json:"type"`                     // Transaction type.
-	UpdatedAt     string      `json:"updated_at"`               // Last update timestamp.
+	AccountName   string            `json:"account_name"`             // Account holder name.
+	AccountNumber string            `json:"account_number"`           // Account number.
+	Amount        float64           `json:"amount"`                   // Transaction amount.
+	BankCode      string            `json:"bank_code"`                // Bank code.
+	BankName      string            `json:"bank_name"`                // Bank name.
+	Bill          *BillDetail       `json:"bill,omitempty"`           // Bill detail payload.
+	Category      string            `json:"category"`                 // Category identifier.
+	Charges       float64           `json:"charges"`                  // Transaction charges.
+	CreatedAt     Timestamp         `json:"created_at"`               // Creation timestamp.
+	Detail        string            `json:"detail"`                   // Transaction detail.
+	FiatRate      float64           `json:"fiat_rate"`                // Fiat conversion rate.
+	ID            string            `json:"id"`                       // Transaction ID.
+	Imad          string            `json:"imad,omitempty"`           // IMAD reference.
+	PaymentMethod string            `json:"payment_method,omitempty"` // Payment method.
+	Reference     string            `json:"reference"`                // Reference string.
+	Report        bool              `json:"report"`                   // Report status.
+	ReportMessage string            `json:"report_message,omitempty"` // Report message.
+	SessionID     string            `json:"session_id,omitempty"`     // Session ID.
+	Status        TransactionStatus `json:"status"`                   // Transaction status.
+	TraceNumber   string            `json:"trace_number,omitempty"`   // Trace number.
+	Type          TransactionType   `json:"type"`                     // Transaction type.
+	UpdatedAt     Timestamp         `json:"updated_at"`               // Last update timestamp.
 }
 
 // CreateBillResponse represents the response from creating a bill.
 type CreateBillResponse struct {
 	Message     string          `json:"message"`     // Response message.
 	Transaction BillTransaction `json:"transaction"` // Transaction details.
+
+	IdempotencyKey string `json:"-"` // Idempotency key the bill was created with.
 }
 
 // BillInt defines bill-related operations.
 type BillInt interface {
-	Create(ctx context.Context, body *CreateBillBody) (*CreateBillResponse, error)     // Create a new bill.
-	Get(ctx context.Context, id string) (*BillTransaction, error)                      // Retrieve a bill by ID.
-	Categories(ctx context.Context, query *PageAndLimitQuery) ([]*BillCategory, error) // List bill categories.
-	CategoryLists(ctx context.Context, id string) ([]*BillerList, error)               // List billers for a category.
-	CategoryListItems(ctx context.Context, id, itemId string) ([]*BillerItem, error)   // List biller items.
-	Validate(ctx context.Context, body *ValidateBillBody) error                        // Validate a bill with customer details.
+	Create(ctx context.Context, body *CreateBillBody, opts ...CallOption) (*CreateBillResponse, error)     // Create a new bill.
+	Get(ctx context.Context, id string, opts ...CallOption) (*BillTransaction, error)                      // Retrieve a bill by ID.
+	Categories(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*BillCategory, error) // List bill categories.
+	CategoryLists(ctx context.Context, id string, opts ...CallOption) ([]*BillerList, error)               // List billers for a category.
+	CategoryListItems(ctx context.Context, id, itemId string, opts ...CallOption) ([]*BillerItem, error)   // List biller items.
+	Validate(ctx context.Context, body *ValidateBillBody, opts ...CallOption) error                        // Validate a bill with customer details.
 }
 
 // BillIntImpl implements BillInt.
@@ -105,9 +138,17 @@ type BillIntImpl struct {
 var _ BillInt = &BillIntImpl{}
 
 // Create creates a bill.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// body has a Reference, which the API deduplicates bills by.
 // https://docs.swervpay.co/api-reference/bills/create
-func (b BillIntImpl) Create(ctx context.Context, body *CreateBillBody) (*CreateBillResponse, error) {
-	req, err := b.client.NewRequest(ctx, http.MethodPost, "bills", body)
+func (b BillIntImpl) Create(ctx context.Context, body *CreateBillBody, opts ...CallOption) (*CreateBillResponse, error) {
+	reference := ""
+	if body != nil {
+		reference = body.Reference
+	}
+
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bills", body, idempotent(opts, reference)...)
 	if err != nil {
 		return nil, err
 	}
@@ -119,13 +160,15 @@ func (b BillIntImpl) Create(ctx context.Context, body *CreateBillBody) (*CreateB
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
 
 // Get retrieves a bill by its ID.
 // https://docs.swervpay.co/api-reference/bills/get
-func (b BillIntImpl) Get(ctx context.Context, id string) (*BillTransaction, error) {
-	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/"+id, nil)
+func (b BillIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*BillTransaction, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -142,10 +185,10 @@ func (b BillIntImpl) Get(ctx context.Context, id string) (*BillTransaction, erro
 
 // Categories lists bill categories.
 // https://docs.swervpay.co/api-reference/bills/categories
-func (b BillIntImpl) Categories(ctx context.Context, query *PageAndLimitQuery) ([]*BillCategory, error) {
+func (b BillIntImpl) Categories(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*BillCategory, error) {
 	path := GenerateURLPath("bills/categories", query)
 
-	req, err := b.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := b.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +205,8 @@ func (b BillIntImpl) Categories(ctx context.Context, query *PageAndLimitQuery) (
 
 // CategoryLists lists billers for a category.
 // https://docs.swervpay.co/api-reference/bills/category-list
-func (b BillIntImpl) CategoryLists(ctx context.Context, id string) ([]*BillerList, error) {
-	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/categories/"+id, nil)
+func (b BillIntImpl) CategoryLists(ctx context.Context, id string, opts ...CallOption) ([]*BillerList, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/categories/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -180,8 +223,8 @@ func (b BillIntImpl) CategoryLists(ctx context.Context, id string) ([]*BillerLis
 
 // CategoryListItems lists biller items for a category.
 // https://docs.swervpay.co/api-reference/bills/category-list-items
-func (b BillIntImpl) CategoryListItems(ctx context.Context, id, itemId string) ([]*BillerItem, error) {
-	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/categories/"+id+"/items/"+itemId, nil)
+func (b BillIntImpl) CategoryListItems(ctx context.Context, id, itemId string, opts ...CallOption) ([]*BillerItem, error) {
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "bills/categories/"+id+"/items/"+itemId, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,8 +241,8 @@ func (b BillIntImpl) CategoryListItems(ctx context.Context, id, itemId string) (
 
 // Validate validates bill details for a customer.
 // https://docs.swervpay.co/api-reference/bills/validate
-func (b BillIntImpl) Validate(ctx context.Context, body *ValidateBillBody) error {
-	req, err := b.client.NewRequest(ctx, http.MethodPost, "bills/validate", body)
+func (b BillIntImpl) Validate(ctx context.Context, body *ValidateBillBody, opts ...CallOption) error {
+	req, err := b.client.NewRequest(ctx, http.MethodPost, "bills/validate", body, opts...)
 	if err != nil {
 		return err
 	}
@@ -63,7 +63,7 @@ func TestCreateBill(t *testing.T) {
 	assert.Equal(t, resp.Message, "Bill created successfully")
 	assert.Equal(t, resp.Transaction.ID, "bill_123456789")
 	assert.Equal(t, resp.Transaction.Amount, 1000.0)
-	assert.Equal(t, resp.Transaction.Status, "PENDING")
+	assert.Equal(t, resp.Transaction.Status, TransactionStatus("PENDING"))
 	assert.NotNil(t, resp.Transaction.Bill)
 	assert.Equal(t, resp.Transaction.Bill.BillCode, "ELEC001")
 }
@@ -107,7 +107,7 @@ func TestGetBill(t *testing.T) {
 	}
 	assert.Equal(t, resp.ID, billId)
 	assert.Equal(t, resp.Amount, 1000.0)
-	assert.Equal(t, resp.Status, "success")
+	assert.Equal(t, resp.Status, TransactionStatus("success"))
 	assert.Equal(t, resp.Charges, 50.0)
 	assert.NotNil(t, resp.Bill)
 }
@@ -9,22 +9,22 @@ import (
 // Business represents a business entity in the Swervpay system.
 // It includes various properties like address, name, country, etc.
 type Business struct {
-	Address   string `json:"address"`    // Address of the business
-	Name      string `json:"name"`       // Name of the business
-	Country   string `json:"country"`    // Country where the business is located
-	CreatedAt string `json:"created_at"` // Time when the business was created
-	Email     string `json:"email"`      // Email of the business
-	ID        string `json:"id"`         // Unique identifier of the business
-	Logo      string `json:"logo"`       // Logo of the business
-	Slug      string `json:"slug"`       // Slug of the business
-	Type      string `json:"type"`       // Type of the business
-	UpdatedAt string `json:"updated_at"` // Time when the business was last updated
+	Address   string    `json:"address"`    // Address of the business
+	Name      string    `json:"name"`       // Name of the business
+	Country   string    `json:"country"`    // Country where the business is located
+	CreatedAt Timestamp `json:"created_at"` // Time when the business was created
+	Email     string    `json:"email"`      // Email of the business
+	ID        string    `json:"id"`         // Unique identifier of the business
+	Logo      string    `json:"logo"`       // Logo of the business
+	Slug      string    `json:"slug"`       // Slug of the business
+	Type      string    `json:"type"`       // Type of the business
+	UpdatedAt Timestamp `json:"updated_at"` // Time when the business was last updated
 }
 
 // BusinessInt is an interface that defines the methods a Business must have.
 type BusinessInt interface {
 	// Get retrieves the Business information from the Swervpay system.
-	Get(ctx context.Context) (*Business, error)
+	Get(ctx context.Context, opts ...CallOption) (*Business, error)
 }
 
 // BusinessIntImpl is a concrete implementation of the BusinessInt interface.
@@ -37,10 +37,10 @@ var _ BusinessInt = &BusinessIntImpl{}
 
 // Get is a method on BusinessIntImpl that retrieves the Business information from the Swervpay system.
 // https://docs.swervpay.co/api-reference/business/get
-func (b *BusinessIntImpl) Get(ctx context.Context) (*Business, error) {
+func (b *BusinessIntImpl) Get(ctx context.Context, opts ...CallOption) (*Business, error) {
 
 	// Create a new request to get the business information
-	req, err := b.client.NewRequest(ctx, http.MethodGet, "business", nil)
+	req, err := b.client.NewRequest(ctx, http.MethodGet, "business", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
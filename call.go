@@ -0,0 +1,187 @@
+package swervpay
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single call to the Swervpay API.
+type CallOption func(*callSettings)
+
+// callSettings holds the per-call configuration collected from CallOptions.
+type callSettings struct {
+	idempotencyKey     string
+	autoIdempotencyKey bool
+	retryable          bool // The call may be resent although its method is not safe.
+	middlewares        []Middleware
+	responseMeta       *ResponseMeta
+	query              interface{}
+	timeout            time.Duration
+	headers            http.Header
+	retry              *RetryPolicy
+	onBehalfOf         string
+	validate           *bool // Overrides the client's validation setting when set.
+}
+
+// callSettingsKey is the context key under which NewRequest stores the call settings.
+type callSettingsKey struct{}
+
+// settingsFromRequest returns the call settings NewRequest attached to req.
+func settingsFromRequest(req *http.Request) *callSettings {
+	if s, ok := req.Context().Value(callSettingsKey{}).(*callSettings); ok {
+		return s
+	}
+	return &callSettings{}
+}
+
+// WithIdempotencyKey sets the idempotency key sent with the call. A call with
+// a key of the caller's is retried on transient failures, with the same key on
+// every attempt.
+func WithIdempotencyKey(key string) CallOption {
+	return func(s *callSettings) {
+		s.idempotencyKey = key
+		s.retryable = key != ""
+	}
+}
+
+// WithRetryableCall lets the retry policy resend a call that is not a GET,
+// for a caller who knows the API will not act on it twice, e.g. because it
+// deduplicates the call by a reference in the body. Other calls that may
+// move money are only resent when they carry an idempotency key passed with
+// WithIdempotencyKey.
+func WithRetryableCall() CallOption {
+	return func(s *callSettings) {
+		s.retryable = true
+	}
+}
+
+// WithCallTimeout bounds the whole call, including token refreshes and
+// retries, by timeout. The caller's context deadline still applies when it is
+// earlier.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(s *callSettings) {
+		s.timeout = timeout
+	}
+}
+
+// WithCallHeader sets a header on the call, replacing any value configured on
+// the client. It may be given more than once.
+func WithCallHeader(key, value string) CallOption {
+	return func(s *callSettings) {
+		if s.headers == nil {
+			s.headers = http.Header{}
+		}
+		s.headers.Set(key, value)
+	}
+}
+
+// WithOnBehalfOf makes the call on behalf of the business with the given id,
+// for platforms managing several businesses. The id is sent in the
+// OnBehalfOfHeader header.
+func WithOnBehalfOf(businessID string) CallOption {
+	return func(s *callSettings) {
+		s.onBehalfOf = businessID
+	}
+}
+
+// WithCallRetryPolicy overrides the client's retry policy for the call.
+// Use &RetryPolicy{MaxAttempts: 1} to disable retries.
+func WithCallRetryPolicy(policy *RetryPolicy) CallOption {
+	return func(s *callSettings) {
+		s.retry = policy
+	}
+}
+
+// ResponseMeta holds metadata about the response to a call.
+type ResponseMeta struct {
+	StatusCode     int           // HTTP status code of the final response, zero when none was received.
+	Header         http.Header   // Headers of the final response.
+	RequestID      string        // Request id taken from the response headers, if any.
+	IdempotencyKey string        // Idempotency key the call was sent with, if any.
+	Latency        time.Duration // Time from sending the first attempt until the call finished.
+	Attempts       int           // Number of attempts made, including retries.
+}
+
+// WithResponseMeta captures metadata about the response into meta once the
+// call returns. It is filled in whether the call succeeds or fails, so the
+// idempotency key of a call that timed out can be looked up.
+func WithResponseMeta(meta *ResponseMeta) CallOption {
+	return func(s *callSettings) {
+		s.responseMeta = meta
+	}
+}
+
+// captureResponse fills in the response metadata requested by the caller, if any.
+func (s *callSettings) captureResponse(resp *http.Response, attempts int, latency time.Duration) {
+	if s.responseMeta == nil {
+		return
+	}
+
+	meta := ResponseMeta{
+		RequestID:      responseRequestID(resp),
+		IdempotencyKey: s.idempotencyKey,
+		Latency:        latency,
+		Attempts:       attempts,
+	}
+	if resp != nil {
+		meta.StatusCode = resp.StatusCode
+		meta.Header = resp.Header
+	}
+	*s.responseMeta = meta
+}
+
+// withAutoIdempotencyKey generates an idempotency key when the caller did not provide one.
+func withAutoIdempotencyKey() CallOption {
+	return func(s *callSettings) {
+		s.autoIdempotencyKey = true
+	}
+}
+
+// idempotent returns opts extended with an automatically generated idempotency
+// key, which is reused on every attempt of the call. A generated key alone does
+// not make the call retryable: that takes a key of the caller's or a reference,
+// which the API deduplicates by.
+// A new slice is returned so that the caller's backing array is never modified.
+func idempotent(opts []CallOption, reference string) []CallOption {
+	out := make([]CallOption, 0, len(opts)+2)
+	out = append(out, opts...)
+	if reference != "" {
+		out = append(out, WithRetryableCall())
+	}
+	return append(out, withAutoIdempotencyKey())
+}
+
+// newCallSettings applies opts and fills in generated values.
+func newCallSettings(opts []CallOption) (*callSettings, error) {
+	s := &callSettings{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+
+	if s.autoIdempotencyKey && s.idempotencyKey == "" {
+		key, err := NewIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		s.idempotencyKey = key
+	}
+
+	return s, nil
+}
+
+// NewIdempotencyKey returns a random UUIDv4 suitable for use as an idempotency key.
+func NewIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,238 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	a, err := NewIdempotencyKey()
+	assert.NoError(t, err)
+	b, err := NewIdempotencyKey()
+	assert.NoError(t, err)
+
+	assert.Regexp(t, uuidPattern, a)
+	assert.NotEqual(t, a, b)
+}
+
+func TestIdempotencyKeyGenerated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent string
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get(IdempotencyKeyHeader)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"reference":"ref_001","id":"payout_001","message":"ok"}`))
+	})
+
+	resp, err := client.Payout.Create(context.Background(), &CreatePayoutBody{Reference: "ref_001", AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100})
+	assert.NoError(t, err)
+	assert.Regexp(t, uuidPattern, sent)
+	assert.Equal(t, resp.IdempotencyKey, sent)
+}
+
+func TestIdempotencyKeyReusedOnRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+
+	var mu sync.Mutex
+	var keys []string
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		n := len(keys)
+		mu.Unlock()
+
+		if n == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"message":"Card funded successfully"}`))
+	})
+
+	resp, err := client.Card.Fund(context.Background(), "card_001", &FundOrWithdrawCardBody{Amount: 10}, WithIdempotencyKey("fund-001"), WithRetryableCall())
+	assert.NoError(t, err)
+	assert.Equal(t, keys, []string{"fund-001", "fund-001"})
+	assert.Equal(t, resp.IdempotencyKey, "fund-001")
+}
+
+func TestMoneyMovingCallNotRetried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+
+	var calls int32
+	var sent string
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		sent = r.Header.Get(IdempotencyKeyHeader)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	var meta ResponseMeta
+	_, err := client.Card.Fund(context.Background(), "card_001", &FundOrWithdrawCardBody{Amount: 10}, WithResponseMeta(&meta))
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// The generated key is reported although the call failed.
+	assert.Regexp(t, uuidPattern, meta.IdempotencyKey)
+	assert.Equal(t, sent, meta.IdempotencyKey)
+}
+
+func TestIdempotentDoesNotModifyOptions(t *testing.T) {
+	opts := make([]CallOption, 1, 4)
+	opts[0] = WithIdempotencyKey("key")
+
+	out := idempotent(opts, "")
+	assert.Len(t, out, 2)
+	assert.Len(t, opts, 1)
+	assert.Nil(t, opts[:2][1])
+
+	s, err := newCallSettings(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "key", s.idempotencyKey)
+	assert.True(t, s.retryable)
+
+	// A generated key does not make the call retryable, a reference does.
+	s, err = newCallSettings(idempotent(nil, ""))
+	assert.NoError(t, err)
+	assert.Regexp(t, uuidPattern, s.idempotencyKey)
+	assert.False(t, s.retryable)
+
+	s, err = newCallSettings(idempotent(nil, "ref_001"))
+	assert.NoError(t, err)
+	assert.True(t, s.retryable)
+}
+
+func TestResponseMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	calls := 0
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-Id", "req_00"+strconv.Itoa(calls))
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = w.Write([]byte(`{"id":"card_001"}`))
+	})
+
+	var meta ResponseMeta
+	card, err := client.Card.Get(context.Background(), "card_001", WithResponseMeta(&meta))
+	assert.NoError(t, err)
+	assert.Equal(t, card.ID, "card_001")
+	assert.Equal(t, meta.StatusCode, http.StatusOK)
+	assert.Equal(t, meta.RequestID, "req_002")
+	assert.Equal(t, meta.Header.Get("Cache-Control"), "max-age=60")
+	assert.Equal(t, meta.Attempts, 2)
+	assert.Greater(t, meta.Latency, time.Duration(0))
+}
+
+func TestResponseMetaOnError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_001")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid account number"}`))
+	})
+
+	var meta ResponseMeta
+	_, err := client.Payout.Create(context.Background(), &CreatePayoutBody{Reference: "ref_001", AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100}, WithResponseMeta(&meta))
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, meta.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, meta.RequestID, "req_001")
+	assert.Equal(t, meta.Attempts, 1)
+}
+
+func TestCallTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 1}
+
+	mux.HandleFunc("/fx/rate", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	start := time.Now()
+	_, err := client.Fx.Rate(context.Background(), FxBody{From: "USD", To: "NGN", Amount: 100}, WithCallTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestCallHeader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.headers = map[string]string{"X-Team": "payments", "X-Source": "client"}
+
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("X-Team"), "payments")
+		assert.Equal(t, r.Header.Get("X-Source"), "call")
+		assert.Equal(t, r.Header.Get("X-Trace"), "trace_001")
+		_, _ = w.Write([]byte(`{"reference":"ref_001","id":"payout_001","message":"ok"}`))
+	})
+
+	_, err := client.Payout.Create(context.Background(), &CreatePayoutBody{Reference: "ref_001", AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100},
+		WithCallHeader("X-Source", "call"), WithCallHeader("X-Trace", "trace_001"))
+	assert.NoError(t, err)
+}
+
+func TestOnBehalfOf(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []string
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get(OnBehalfOfHeader))
+		_, _ = w.Write([]byte(`{"id":"card_001"}`))
+	})
+
+	_, err := client.Card.Get(context.Background(), "card_001", WithOnBehalfOf("biz_002"))
+	assert.NoError(t, err)
+
+	_, err = client.Card.Get(context.Background(), "card_001")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"biz_002", ""}, sent)
+}
+
+func TestCallRetryPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/transactions/txn_001", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.Transaction.Get(context.Background(), "txn_001", WithCallRetryPolicy(&RetryPolicy{MaxAttempts: 1}))
+	assert.Error(t, err)
+	assert.Equal(t, calls, 1)
+}
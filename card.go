@@ -2,65 +2,109 @@ package swervpay
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
 // Card represents a card with its details.
 type Card struct {
-	AddressCity       string  `json:"address_city"`        // City of the card holder's address.
-	AddressCountry    string  `json:"address_country"`     // Country of the card holder's address.
-	AddressPostalCode string  `json:"address_postal_code"` // Postal code of the card holder's address.
-	AddressState      string  `json:"address_state"`       // State of the card holder's address.
-	AddressStreet     string  `json:"address_street"`      // Street of the card holder's address.
-	Balance           float64 `json:"balance"`             // Balance on the card.
-	CardNumber        string  `json:"card_number"`         // Card number.
-	CreatedAt         string  `json:"created_at"`          // Creation date of the card.
-	Currency          string  `json:"currency"`            // Currency of the card.
-	Cvv               string  `json:"cvv"`                 // CVV of the card.
-	Expiry            string  `json:"expiry"`              // Expiry date of the card.
-	Freeze            bool    `json:"freeze"`              // Freeze status of the card.
-	ID                string  `json:"id"`                  // ID of the card.
-	Issuer            string  `json:"issuer"`              // Issuer of the card.
-	MaskedPan         string  `json:"masked_pan"`          // Masked PAN of the card.
-	NameOnCard        string  `json:"name_on_card"`        // Name on the card.
-	Status            string  `json:"status"`              // Status of the card.
-	TotalFunded       float64 `json:"total_funded"`        // Total funded amount on the card.
-	Type              string  `json:"type"`                // Type of the card.
-	UpdatedAt         string  `json:"updated_at"`          // Last update date of the card.
-	EncryptedDetails  string  `json:"encrypted_details"`   // Encrypted details of the card.
+	AddressCity       string     `json:"address_city"`        // City of the card holder's address.
+	AddressCountry    string     `json:"address_country"`     // Country of the card holder's address.
+	AddressPostalCode string     `json:"address_postal_code"` // Postal code of the card holder's address.
+	AddressState      string     `json:"address_state"`       // State of the card holder's address.
+	AddressStreet     string     `json:"address_street"`      // Street of the card holder's address.
+	Balance           float64    `json:"balance"`             // Balance on the card.
+	CardNumber        string     `json:"card_number"`         // Card number.
+	CreatedAt         Timestamp  `json:"created_at"`          // Creation date of the card.
+	Currency          Currency   `json:"currency"`            // Currency of the card.
+	Cvv               string     `json:"cvv"`                 // CVV of the card.
+	Expiry            string     `json:"expiry"`              // Expiry date of the card.
+	Freeze            bool       `json:"freeze"`              // Freeze status of the card.
+	ID                string     `json:"id"`                  // ID of the card.
+	Issuer            CardIssuer `json:"issuer"`              // Issuer of the card.
+	MaskedPan         string     `json:"masked_pan"`          // Masked PAN of the card.
+	NameOnCard        string     `json:"name_on_card"`        // Name on the card.
+	Status            CardStatus `json:"status"`              // Status of the card.
+	TotalFunded       float64    `json:"total_funded"`        // Total funded amount on the card.
+	Type              CardType   `json:"type"`                // Type of the card.
+	UpdatedAt         Timestamp  `json:"updated_at"`          // Last update date of the card.
+	EncryptedDetails  string     `json:"encrypted_details"`   // Encrypted details of the card.
+
+	Extra map[string]json.RawMessage `json:"-"` // Fields returned by the API that the model does not have yet
+}
+
+// UnmarshalJSON decodes a card, keeping unknown fields in Extra.
+func (c *Card) UnmarshalJSON(data []byte) error {
+	type card Card
+	if err := json.Unmarshal(data, (*card)(c)); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, (*card)(c))
+	if err != nil {
+		return err
+	}
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes a card, including the fields kept in Extra.
+func (c Card) MarshalJSON() ([]byte, error) {
+	type card Card
+	return marshalWithExtra(card(c), c.Extra)
+}
+
+func (c *Card) extraFields() map[string]json.RawMessage {
+	return c.Extra
+}
+
+// BalanceDecimal returns Balance as an exact decimal.
+func (c Card) BalanceDecimal() Amount {
+	return AmountFromFloat(c.Balance)
+}
+
+// BalanceMoney returns Balance in the card's currency.
+func (c Card) BalanceMoney() Money {
+	return NewMoney(c.BalanceDecimal(), c.Currency)
+}
+
+// TotalFundedDecimal returns TotalFunded as an exact decimal.
+func (c Card) TotalFundedDecimal() Amount {
+	return AmountFromFloat(c.TotalFunded)
 }
 
 // CardTransactionHistory represents a card's transaction history.
 type CardTransactionHistory struct {
-	Amount             float64 `json:"amount"`               // Transaction amount.
-	Category           string  `json:"category"`             // Category of the transaction.
-	Charges            float64 `json:"charges"`              // Charges of the transaction.
-	CreatedAt          string  `json:"created_at"`           // Creation date of the transaction.
-	Currency           string  `json:"currency"`             // Currency of the transaction.
-	ID                 string  `json:"id"`                   // ID of the transaction.
-	MerchantCity       string  `json:"merchant_city"`        // City of the merchant.
-	MerchantCountry    string  `json:"merchant_country"`     // Country of the merchant.
-	MerchantMcc        string  `json:"merchant_mcc"`         // MCC of the merchant.
-	MerchantMid        string  `json:"merchant_mid"`         // MID of the merchant.
-	MerchantName       string  `json:"merchant_name"`        // Name of the merchant.
-	MerchantPostalCode string  `json:"merchant_postal_code"` // Postal code of the merchant.
-	MerchantState      string  `json:"merchant_state"`       // State of the merchant.
-	Reference          string  `json:"reference"`            // Reference of the transaction.
-	Report             bool    `json:"report"`               // Report status of the transaction.
-	ReportMessage      string  `json:"report_message"`       // Report message of the transaction.
-	Status             string  `json:"status"`               // Status of the transaction.
-	Type               string  `json:"type"`                 // Type of the transaction.
-	UpdatedAt          string  `json:"updated_at"`           // Last update date of the transaction.
+	Amount             float64   `json:"amount"`               // Transaction amount.
+	Category           string    `json:"category"`             // Category of the transaction.
+	Charges            float64   `json:"charges"`              // Charges of the transaction.
+	CreatedAt          Timestamp `json:"created_at"`           // Creation date of the transaction.
+	Currency           Currency  `json:"currency"`             // Currency of the transaction.
+	ID                 string    `json:"id"`                   // ID of the transaction.
+	MerchantCity       string    `json:"merchant_city"`        // City of the merchant.
+	MerchantCountry    string    `json:"merchant_country"`     // Country of the merchant.
+	MerchantMcc        string    `json:"merchant_mcc"`         // MCC of the merchant.
+	MerchantMid        string    `json:"merchant_mid"`         // MID of the merchant.
+	MerchantName       string    `json:"merchant_name"`        // Name of the merchant.
+	MerchantPostalCode string    `json:"merchant_postal_code"` // Postal code of the merchant.
+	MerchantState      string    `json:"merchant_state"`       // State of the merchant.
+	Reference          string    `json:"reference"`            // Reference of the transaction.
+	Report             bool      `json:"report"`               // Report status of the transaction.
+	ReportMessage      string    `json:"report_message"`       // Report message of the transaction.
+	Status             string    `json:"status"`               // Status of the transaction.
+	Type               string    `json:"type"`                 // Type of the transaction.
+	UpdatedAt          Timestamp `json:"updated_at"`           // Last update date of the transaction.
 }
 
 // CreateCardBody represents the body of a card creation request.
 type CreateCardBody struct {
 	Amount        float64                 `json:"amount"`         // Amount to be loaded on the card.
 	CustomerId    string                  `json:"customer_id"`    // ID of the customer.
-	Issuer        string                  `json:"issuer"`         // Issuer of the card.
+	Issuer        CardIssuer              `json:"issuer"`         // Issuer of the card.
 	NameOnCard    string                  `json:"name_on_card"`   // Name to be printed on the card.
-	Currency      string                  `json:"currency"`       // Currency of the card.
-	Type          string                  `json:"type"`           // Type of the card.
+	Currency      Currency                `json:"currency"`       // Currency of the card.
+	Type          CardType                `json:"type"`           // Type of the card.
 	PhoneNumber   string                  `json:"phone_number"`   // Phone number of the card holder.
 	ExpiryDate    string                  `json:"expiry_date"`    // Expiry date of the card.
 	RCNumber      string                  `json:"rc_number"`      // RC number of the card.
@@ -69,6 +113,30 @@ type CreateCardBody struct {
 	Document      CreateCardDocumentInput `json:"document"`       // Document of the card.
 }
 
+// Validate checks the card before it is sent. Business cards need the
+// business's RC number, director BVN and email instead of a customer.
+func (b CreateCardBody) Validate() error {
+	v := newValidation("CreateCardBody")
+	if oneOf(b.Type, CardTypeBusiness) {
+		v.required("rc_number", b.RCNumber)
+		if v.required("director_bvn", b.DirectorBvn) {
+			v.format("director_bvn", b.DirectorBvn, elevenDigits)
+		}
+		if v.required("business_email", b.BusinessEmail) {
+			v.email("business_email", b.BusinessEmail)
+		}
+	} else {
+		v.required("customer_id", b.CustomerId)
+	}
+	v.currency("currency", b.Currency, false)
+	v.nonNegative("amount", b.Amount)
+	if b.Document.DocumentType != "" || b.Document.DocumentNumber != "" {
+		v.required("document.document_type", b.Document.DocumentType)
+		v.required("document.document_number", b.Document.DocumentNumber)
+	}
+	return v.result()
+}
+
 type CreateCardDocumentInput struct {
 	DocumentType   string `json:"document_type"`
 	DocumentNumber string `json:"document_number"`
@@ -85,6 +153,8 @@ type CardCreationResponse struct {
 type CardActionResponse struct {
 	Message     string       `json:"message"`     // Response message.
 	Transaction *Transaction `json:"transaction"` // Transaction details.
+
+	IdempotencyKey string `json:"-"` // Idempotency key the action was sent with.
 }
 
 // FundOrWithdrawCardBody represents the body of a fund or withdraw request.
@@ -92,19 +162,26 @@ type FundOrWithdrawCardBody struct {
 	Amount float64 `json:"amount"` // Amount to be funded or withdrawn.
 }
 
+// Validate checks the amount before the request is sent.
+func (b FundOrWithdrawCardBody) Validate() error {
+	v := newValidation("FundOrWithdrawCardBody")
+	v.positive("amount", b.Amount)
+	return v.result()
+}
+
 // CardInt is the interface for card operations.
 type CardInt interface {
-	Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Card, error)                                      // Gets multiple cards.
-	Get(ctx context.Context, id string) (*Card, error)                                                        // Gets a single card.
-	Create(ctx context.Context, body *CreateCardBody) (*CardCreationResponse, error)                          // Creates a card.
-	Fund(ctx context.Context, id string, body *FundOrWithdrawCardBody) (*CardActionResponse, error)           // Funds a card.
-	Withdraw(ctx context.Context, id string, body *FundOrWithdrawCardBody) (*CardActionResponse, error)       // Withdraws from a card.
-	Terminate(ctx context.Context, id string) (*DefaultResponse, error)                                       // Terminates a card.
-	Freeze(ctx context.Context, id string) (*DefaultResponse, error)                                          // Freezes a card.
-	Unfreeze(ctx context.Context, id string) (*DefaultResponse, error)                                        // Unfreezes a card.
-	Regularize(ctx context.Context, id string) (*DefaultResponse, error)                                      // Regularizes a card.
-	Transactions(ctx context.Context, id string, query *PageAndLimitQuery) ([]*CardTransactionHistory, error) // Gets multiple transactions of a card.
-	Transaction(ctx context.Context, id string, transactionId string) (*CardTransactionHistory, error)        // Gets a single transaction of a card.
+	Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Card, error)                                      // Gets multiple cards.
+	Get(ctx context.Context, id string, opts ...CallOption) (*Card, error)                                                        // Gets a single card.
+	Create(ctx context.Context, body *CreateCardBody, opts ...CallOption) (*CardCreationResponse, error)                          // Creates a card.
+	Fund(ctx context.Context, id string, body *FundOrWithdrawCardBody, opts ...CallOption) (*CardActionResponse, error)           // Funds a card.
+	Withdraw(ctx context.Context, id string, body *FundOrWithdrawCardBody, opts ...CallOption) (*CardActionResponse, error)       // Withdraws from a card.
+	Terminate(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)                                       // Terminates a card.
+	Freeze(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)                                          // Freezes a card.
+	Unfreeze(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)                                        // Unfreezes a card.
+	Regularize(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)                                      // Regularizes a card.
+	Transactions(ctx context.Context, id string, query *PageAndLimitQuery, opts ...CallOption) ([]*CardTransactionHistory, error) // Gets multiple transactions of a card.
+	Transaction(ctx context.Context, id string, transactionId string, opts ...CallOption) (*CardTransactionHistory, error)        // Gets a single transaction of a card.
 }
 
 // CardIntImpl is the implementation of the CardInt interface.
@@ -117,10 +194,10 @@ var _ CardInt = &CardIntImpl{}
 
 // Gets gets multiple cards.
 // https://docs.swervpay.co/api-reference/cards/get-all-cards
-func (c CardIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Card, error) {
+func (c CardIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Card, error) {
 	path := GenerateURLPath("cards", query)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +215,8 @@ func (c CardIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Car
 
 // Get gets a single card.
 // https://docs.swervpay.co/api-reference/cards/get
-func (c CardIntImpl) Get(ctx context.Context, id string) (*Card, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, "cards/"+id, nil)
+func (c CardIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Card, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, "cards/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,8 +234,8 @@ func (c CardIntImpl) Get(ctx context.Context, id string) (*Card, error) {
 
 // Create creates a card.
 // https://docs.swervpay.co/api-reference/cards/create
-func (c CardIntImpl) Create(ctx context.Context, body *CreateCardBody) (*CardCreationResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards", body)
+func (c CardIntImpl) Create(ctx context.Context, body *CreateCardBody, opts ...CallOption) (*CardCreationResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -175,9 +252,12 @@ func (c CardIntImpl) Create(ctx context.Context, body *CreateCardBody) (*CardCre
 }
 
 // Fund funds a card.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// marked with WithRetryableCall.
 // https://docs.swervpay.co/api-reference/cards/fund-card
-func (c CardIntImpl) Fund(ctx context.Context, id string, body *FundOrWithdrawCardBody) (*CardActionResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/fund", body)
+func (c CardIntImpl) Fund(ctx context.Context, id string, body *FundOrWithdrawCardBody, opts ...CallOption) (*CardActionResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/fund", body, idempotent(opts, "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -190,13 +270,18 @@ func (c CardIntImpl) Fund(ctx context.Context, id string, body *FundOrWithdrawCa
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
 
 // Withdraw withdraws from a card.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// marked with WithRetryableCall.
 // https://docs.swervpay.co/api-reference/cards/withdraw-from-card
-func (c CardIntImpl) Withdraw(ctx context.Context, id string, body *FundOrWithdrawCardBody) (*CardActionResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/withdraw", body)
+func (c CardIntImpl) Withdraw(ctx context.Context, id string, body *FundOrWithdrawCardBody, opts ...CallOption) (*CardActionResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/withdraw", body, idempotent(opts, "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -209,13 +294,15 @@ func (c CardIntImpl) Withdraw(ctx context.Context, id string, body *FundOrWithdr
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
 
 // Terminate terminates a card.
 // https://docs.swervpay.co/api-reference/cards/terminate
-func (c CardIntImpl) Terminate(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/terminate", nil)
+func (c CardIntImpl) Terminate(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/terminate", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -233,8 +320,8 @@ func (c CardIntImpl) Terminate(ctx context.Context, id string) (*DefaultResponse
 
 // Freeze freezes a card.
 // https://docs.swervpay.co/api-reference/cards/freeze
-func (c CardIntImpl) Freeze(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/freeze", nil)
+func (c CardIntImpl) Freeze(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/freeze", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -252,8 +339,8 @@ func (c CardIntImpl) Freeze(ctx context.Context, id string) (*DefaultResponse, e
 
 // Unfreeze unfreezes a card.
 // https://docs.swervpay.co/api-reference/cards/unfreeze
-func (c CardIntImpl) Unfreeze(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/unfreeze", nil)
+func (c CardIntImpl) Unfreeze(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/unfreeze", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -271,8 +358,8 @@ func (c CardIntImpl) Unfreeze(ctx context.Context, id string) (*DefaultResponse,
 
 // Regularize regularizes a card.
 // https://docs.swervpay.co/api-reference/cards/regularize
-func (c CardIntImpl) Regularize(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/regularize", nil)
+func (c CardIntImpl) Regularize(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "cards/"+id+"/regularize", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -290,10 +377,10 @@ func (c CardIntImpl) Regularize(ctx context.Context, id string) (*DefaultRespons
 
 // Transactions gets multiple transactions of a card.
 // https://docs.swervpay.co/api-reference/cards/transactions
-func (c CardIntImpl) Transactions(ctx context.Context, id string, query *PageAndLimitQuery) ([]*CardTransactionHistory, error) {
+func (c CardIntImpl) Transactions(ctx context.Context, id string, query *PageAndLimitQuery, opts ...CallOption) ([]*CardTransactionHistory, error) {
 	path := GenerateURLPath("cards/"+id+"/transactions", query)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -311,9 +398,9 @@ func (c CardIntImpl) Transactions(ctx context.Context, id string, query *PageAnd
 
 // Transaction get transactions of a card.
 // https://docs.swervpay.co/api-reference/cards/get-transaction
-func (c CardIntImpl) Transaction(ctx context.Context, id string, transactionId string) (*CardTransactionHistory, error) {
+func (c CardIntImpl) Transaction(ctx context.Context, id string, transactionId string, opts ...CallOption) (*CardTransactionHistory, error) {
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, "cards/"+id+"/transactions/"+transactionId, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, "cards/"+id+"/transactions/"+transactionId, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
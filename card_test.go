@@ -49,7 +49,7 @@ func TestFundCard(t *testing.T) {
 	if assert.NotNil(t, resp.Transaction) {
 		assert.Equal(t, resp.Transaction.ID, "txn_card_fund_001")
 		assert.Equal(t, resp.Transaction.Amount, 1000.0)
-		assert.Equal(t, resp.Transaction.Status, "success")
+		assert.Equal(t, resp.Transaction.Status, TransactionStatus("success"))
 	}
 }
 
@@ -93,6 +93,6 @@ func TestWithdrawCard(t *testing.T) {
 	if assert.NotNil(t, resp.Transaction) {
 		assert.Equal(t, resp.Transaction.ID, "txn_card_withdraw_001")
 		assert.Equal(t, resp.Transaction.Amount, 500.0)
-		assert.Equal(t, resp.Transaction.Status, "success")
+		assert.Equal(t, resp.Transaction.Status, TransactionStatus("success"))
 	}
 }
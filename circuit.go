@@ -0,0 +1,293 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched through errors.Is by the *CircuitOpenError
+// returned when a call is refused because its circuit breaker is open.
+var ErrCircuitOpen = errors.New("swervpay: circuit open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests flow and failures are counted.
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen.
+	CircuitHalfOpen                     // A limited number of probe requests is let through.
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitOpenError is returned when a call is refused by an open circuit breaker,
+// or by a half-open one whose probe requests are all in flight.
+type CircuitOpenError struct {
+	Group     string    // Route group whose breaker is open, e.g. "cards".
+	OpenUntil time.Time // Time at which the call may be tried again.
+	HalfOpen  bool      // The breaker is half-open and OpenUntil is only an estimate of when its probes finish.
+}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	if e.HalfOpen {
+		return fmt.Sprintf("swervpay: circuit half-open for %q, probing until at least %s", e.Group, e.OpenUntil.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("swervpay: circuit open for %q until %s", e.Group, e.OpenUntil.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitStateChange describes a transition of a circuit breaker.
+type CircuitStateChange struct {
+	Group string       // Route group of the breaker.
+	From  CircuitState // Previous state.
+	To    CircuitState // New state.
+}
+
+// CircuitBreakerConfig configures the circuit breakers of a client. Every
+// route group has its own breaker. Network errors, timeouts and 5xx responses
+// count as failures; a request canceled by its caller counts as neither a
+// failure nor a success.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open a breaker; 5 when zero.
+	OpenTimeout      time.Duration // Time a breaker stays open before probing; 30 seconds when zero.
+	HalfOpenRequests int           // Concurrent probe requests allowed while half-open; 1 when zero.
+
+	// Group maps a templated route such as "cards/{id}/fund" to its group.
+	// When nil, routes are grouped by their first segment, e.g. "cards".
+	Group func(route string) string
+
+	// OnStateChange is called after a breaker changes state. It is called
+	// synchronously and must not block.
+	OnStateChange func(change CircuitStateChange)
+}
+
+// WithCircuitBreaker enables circuit breaking per route group.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(o *clientOptions) error {
+		breaker, err := newCircuitBreaker(config)
+		if err != nil {
+			return err
+		}
+		o.breaker = breaker
+		return nil
+	}
+}
+
+// circuitBreaker holds the breakers of every route group seen so far.
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	groups map[string]*circuit
+}
+
+// circuit is the breaker of one route group.
+type circuit struct {
+	state      CircuitState
+	failures   int       // Consecutive failures while closed.
+	openUntil  time.Time // End of the open period.
+	probes     int       // Probe requests in flight while half-open.
+	generation uint64    // Incremented on every transition, so late outcomes of older states are ignored.
+}
+
+func newCircuitBreaker(config CircuitBreakerConfig) (*circuitBreaker, error) {
+	if config.FailureThreshold < 0 || config.OpenTimeout < 0 || config.HalfOpenRequests < 0 {
+		return nil, fmt.Errorf("%w: circuit breaker settings must not be negative", ErrInvalidConfig)
+	}
+
+	if config.FailureThreshold == 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout == 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenRequests == 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	return &circuitBreaker{config: config, now: time.Now, groups: map[string]*circuit{}}, nil
+}
+
+// allow reports whether a request to route may be sent. When it may, the
+// returned function must be called with the outcome of the attempt.
+func (b *circuitBreaker) allow(ctx context.Context, route string) (func(*http.Response, error), error) {
+	if b == nil {
+		return func(*http.Response, error) {}, nil
+	}
+
+	group := b.group(route)
+
+	b.mu.Lock()
+	c := b.circuit(group)
+
+	var changes []CircuitStateChange
+	if c.state == CircuitOpen && !b.now().Before(c.openUntil) {
+		changes = append(changes, b.transition(group, c, CircuitHalfOpen))
+	}
+
+	switch {
+	case c.state == CircuitOpen:
+		openUntil := c.openUntil
+		b.mu.Unlock()
+		b.notify(changes)
+		return nil, &CircuitOpenError{Group: group, OpenUntil: openUntil}
+	case c.state == CircuitHalfOpen && c.probes >= b.config.HalfOpenRequests:
+		// The open period is over, so callers are pointed a probe interval ahead instead.
+		retryAt := b.now().Add(b.probeInterval())
+		b.mu.Unlock()
+		b.notify(changes)
+		return nil, &CircuitOpenError{Group: group, OpenUntil: retryAt, HalfOpen: true}
+	case c.state == CircuitHalfOpen:
+		c.probes++
+	}
+
+	generation := c.generation
+	b.mu.Unlock()
+	b.notify(changes)
+
+	var once sync.Once
+	return func(resp *http.Response, err error) {
+		once.Do(func() { b.record(ctx, group, generation, resp, err) })
+	}, nil
+}
+
+// halfOpenProbeInterval is how long callers refused by a half-open breaker are
+// asked to wait, unless the open timeout is shorter.
+const halfOpenProbeInterval = time.Second
+
+// probeInterval returns the wait before a call refused while half-open is tried again.
+func (b *circuitBreaker) probeInterval() time.Duration {
+	return min(b.config.OpenTimeout, halfOpenProbeInterval)
+}
+
+// record applies the outcome of an attempt to the breaker of group.
+func (b *circuitBreaker) record(ctx context.Context, group string, generation uint64, resp *http.Response, err error) {
+	b.mu.Lock()
+	c := b.circuit(group)
+	if c.generation != generation {
+		b.mu.Unlock()
+		return
+	}
+
+	var changes []CircuitStateChange
+	switch c.state {
+	case CircuitClosed:
+		switch {
+		case isCircuitFailure(ctx, resp, err):
+			c.failures++
+			if c.failures >= b.config.FailureThreshold {
+				changes = append(changes, b.transition(group, c, CircuitOpen))
+			}
+		case ctx.Err() == nil:
+			c.failures = 0
+		}
+	case CircuitHalfOpen:
+		c.probes--
+		switch {
+		case isCircuitFailure(ctx, resp, err):
+			changes = append(changes, b.transition(group, c, CircuitOpen))
+		case ctx.Err() == nil:
+			changes = append(changes, b.transition(group, c, CircuitClosed))
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(changes)
+}
+
+// transition moves c to state. It must be called with b.mu held.
+func (b *circuitBreaker) transition(group string, c *circuit, state CircuitState) CircuitStateChange {
+	change := CircuitStateChange{Group: group, From: c.state, To: state}
+
+	c.state = state
+	c.failures = 0
+	c.probes = 0
+	c.generation++
+	if state == CircuitOpen {
+		c.openUntil = b.now().Add(b.config.OpenTimeout)
+	}
+
+	return change
+}
+
+// notify reports state changes to the callback, outside of the lock.
+func (b *circuitBreaker) notify(changes []CircuitStateChange) {
+	if b.config.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.config.OnStateChange(change)
+	}
+}
+
+// circuit returns the breaker of group, creating it if needed. It must be called with b.mu held.
+func (b *circuitBreaker) circuit(group string) *circuit {
+	c, ok := b.groups[group]
+	if !ok {
+		c = &circuit{}
+		b.groups[group] = c
+	}
+	return c
+}
+
+// group returns the route group of route.
+func (b *circuitBreaker) group(route string) string {
+	if b.config.Group != nil {
+		return b.config.Group(route)
+	}
+	group, _, _ := strings.Cut(route, "/")
+	return group
+}
+
+// state returns the current state of the breaker of group.
+func (b *circuitBreaker) state(group string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.groups[group]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// CircuitState returns the state of the circuit breaker of a route group,
+// e.g. "cards". It is CircuitClosed when circuit breaking is disabled.
+func (c *SwervpayClient) CircuitState(group string) CircuitState {
+	return c.breaker.state(group)
+}
+
+// isCircuitFailure reports whether an attempt indicates that the API is
+// degraded. Errors caused by the caller's own context are not failures.
+func isCircuitFailure(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,135 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Unix(0, 0)
+	var changes []CircuitStateChange
+	breaker, err := newCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		OnStateChange:    func(c CircuitStateChange) { changes = append(changes, c) },
+	})
+	assert.NoError(t, err)
+	breaker.now = func() time.Time { return now }
+	client.breaker = breaker
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 1}
+
+	var calls, healthy int32
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"card_001"}`))
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Card.Get(context.Background(), "card_001")
+		assert.False(t, errors.Is(err, ErrCircuitOpen))
+	}
+	assert.Equal(t, CircuitOpen, client.CircuitState("cards"))
+
+	_, err = client.Card.Get(context.Background(), "card_001")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	if assert.ErrorAs(t, err, &openErr) {
+		assert.Equal(t, openErr.Group, "cards")
+		assert.Equal(t, openErr.OpenUntil, now.Add(time.Minute))
+	}
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+
+	// Other route groups are not affected.
+	mux.HandleFunc("/banks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	_, err = client.Other.Banks(context.Background())
+	assert.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	atomic.StoreInt32(&healthy, 1)
+
+	resp, err := client.Card.Get(context.Background(), "card_001")
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, "card_001")
+	assert.Equal(t, CircuitClosed, client.CircuitState("cards"))
+
+	assert.Equal(t, []CircuitStateChange{
+		{Group: "cards", From: CircuitClosed, To: CircuitOpen},
+		{Group: "cards", From: CircuitOpen, To: CircuitHalfOpen},
+		{Group: "cards", From: CircuitHalfOpen, To: CircuitClosed},
+	}, changes)
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Unix(0, 0)
+	breaker, err := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	assert.NoError(t, err)
+	breaker.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	failed := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	done, err := breaker.allow(ctx, "payouts")
+	assert.NoError(t, err)
+	done(failed, nil)
+	assert.Equal(t, CircuitOpen, breaker.state("payouts"))
+
+	now = now.Add(time.Second)
+
+	// Only one probe is let through while half-open.
+	probe, err := breaker.allow(ctx, "payouts/{id}")
+	assert.NoError(t, err)
+	_, err = breaker.allow(ctx, "payouts")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// The refused call is pointed past now, not at the elapsed open period.
+	var openErr *CircuitOpenError
+	if assert.ErrorAs(t, err, &openErr) {
+		assert.True(t, openErr.HalfOpen)
+		assert.Equal(t, now.Add(time.Second), openErr.OpenUntil)
+	}
+
+	// A failed probe opens the breaker again.
+	probe(failed, nil)
+	assert.Equal(t, CircuitOpen, breaker.state("payouts"))
+	_, err = breaker.allow(ctx, "payouts")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func TestCircuitBreakerIgnoresCallerCancellation(t *testing.T) {
+	breaker, err := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done, err := breaker.allow(ctx, "wallets")
+	assert.NoError(t, err)
+	done(nil, ctx.Err())
+	assert.Equal(t, CircuitClosed, breaker.state("wallets"))
+
+	// Client errors mean the API is up.
+	done, err = breaker.allow(context.Background(), "wallets")
+	assert.NoError(t, err)
+	done(&http.Response{StatusCode: http.StatusBadRequest}, nil)
+	assert.Equal(t, CircuitClosed, breaker.state("wallets"))
+}
+
+func TestCircuitBreakerInvalidConfig(t *testing.T) {
+	_, err := New(WithCredentials("business", "secret"), WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: -1}))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
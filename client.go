@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 )
 
 const (
@@ -21,9 +25,14 @@ type SwervpayClientOption struct {
 	BusinessID string
 	SecretKey  string
 	Sandbox    bool
-	Timeout    int
-	Version    string
+	Timeout    int    // Request timeout in seconds; no timeout when zero.
+	Version    string // API version, e.g. "v1", set in the base URL path; the base URL's own when empty or not of that form.
 	BaseURL    string
+	Retry      *RetryPolicy // Retry policy for transient failures; DefaultRetryPolicy when nil.
+	TokenStore TokenStore   // Store shared access tokens are kept in; a new MemoryTokenStore when nil.
+
+	Environment         Environment // Environment to talk to; derived from Sandbox and BaseURL when empty.
+	RequireExplicitLive bool        // Refuse the live environment unless Environment is EnvironmentLive.
 }
 
 type AuthResponse struct {
@@ -32,20 +41,39 @@ type AuthResponse struct {
 }
 
 type TokenDetail struct {
-	Type      string `json:"type"`
-	ExpiresAt int64  `json:"expires_at"`
-	IssuedAt  int64  `json:"issued_at"`
+	Type      string    `json:"type"`
+	ExpiresAt Timestamp `json:"expires_at"`
+	IssuedAt  Timestamp `json:"issued_at"`
 }
 
 // SwervpayClient represents a client for interacting with Swervpay API Client.
 type SwervpayClient struct {
-	client      *http.Client
-	Config      *SwervpayClientOption
+	client *http.Client
+	Config *SwervpayClientOption
+
+	// AccessToken seeds the client with an existing access token.
+	//
+	// Deprecated: tokens are managed by the client; this field is read once on
+	// the first request and never updated.
 	AccessToken string
 
+	tokens          *tokenManager
+	seedToken       sync.Once
+	credentialCheck sync.Once // Guards the cross-environment probe of checkCredentials.
+
 	BaseURL *url.URL
 
-	headers map[string]string
+	headers        map[string]string
+	userAgent      string
+	middlewares    []Middleware
+	logger         *slog.Logger
+	metrics        MetricsCollector
+	limiter        *rateLimiter
+	breaker        *circuitBreaker
+	environment    Environment
+	onSchemaDrift  func(SchemaDrift) error
+	skipValidation bool
+	initErr        error // Configuration error reported by every request of a client built by NewSwervpayClient.
 
 	Customer    CustomerInt
 	Card        CardInt
@@ -61,17 +89,75 @@ type SwervpayClient struct {
 }
 
 // NewSwervpayClient creates a new SwervpayClient with the given options.
+// An invalid configuration is reported by every request made with the client;
+// use New to get the error up front.
 func NewSwervpayClient(config *SwervpayClientOption) *SwervpayClient {
+	s, err := newClient(config, &clientOptions{config: config})
+	s.initErr = err
+	return s
+}
+
+// newClient builds a client from config and the options collected by New.
+// The client is returned even when the configuration is invalid.
+func newClient(config *SwervpayClientOption, o *clientOptions) (*SwervpayClient, error) {
+	environment, envErr := resolveEnvironment(config)
+
 	if config.BaseURL == "" {
-		config.BaseURL = "https://api.swervpay.co/api/v1/"
+		config.BaseURL = liveBaseURL
 
-		if config.Sandbox {
-			config.BaseURL = "https://sandbox.swervpay.co/api/v1/"
+		if environment == EnvironmentSandbox {
+			config.BaseURL = sandboxBaseURL
 		}
 	}
-	baseURL, _ := url.Parse(config.BaseURL)
+	baseURL, err := parseBaseURL(config.BaseURL)
+	versioned := true
+	if err == nil {
+		baseURL, versioned = applyAPIVersion(baseURL, config.Version)
+	}
+	if envErr != nil {
+		err = envErr
+	}
 
-	s := &SwervpayClient{client: http.DefaultClient, Config: config, BaseURL: baseURL}
+	s := &SwervpayClient{
+		client:         o.buildHTTPClient(),
+		Config:         config,
+		BaseURL:        baseURL,
+		environment:    environment,
+		onSchemaDrift:  o.onSchemaDrift,
+		skipValidation: o.skipValidation,
+		headers:        o.headers,
+		userAgent:      userAgent,
+		middlewares:    o.middlewares,
+		logger:         o.logger,
+		metrics:        o.metrics,
+		limiter:        o.limiter,
+		breaker:        o.breaker,
+	}
+
+	if o.userAgentSuffix != "" {
+		s.userAgent += " " + o.userAgentSuffix
+	}
+
+	// Versions were not checked before, so a version that cannot be applied
+	// is left out rather than failing every call.
+	if !versioned {
+		logger := s.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("swervpay: API version not applied, expected a version like v1 and a base URL ending in one",
+			slog.String("version", config.Version), slog.String("base_url", baseURL.String()))
+	}
+
+	var refresh tokenRefreshFunc
+	if config.BusinessID != "" || config.SecretKey != "" {
+		refresh = s.authenticate
+	}
+	store := config.TokenStore
+	if store == nil {
+		store = NewMemoryTokenStore()
+	}
+	s.tokens = newTokenManager(refresh, store, tokenStoreKey(baseURL, config.BusinessID))
 
 	s.Fx = &FxIntImpl{client: s}
 	s.Business = &BusinessIntImpl{client: s}
@@ -85,16 +171,50 @@ func NewSwervpayClient(config *SwervpayClientOption) *SwervpayClient {
 	s.Collection = &CollectionIntImpl{client: s}
 	s.Bill = &BillIntImpl{client: s}
 
-	return s
+	return s, err
 }
 
-// NewRequest creates a new request to the Swervpay API.
-func (c *SwervpayClient) NewRequest(ctx context.Context, method, path string, params interface{}) (*http.Request, error) {
+// NewRequest creates a new request to the Swervpay API. Request bodies with
+// a Validate method are validated first, unless validation is turned off.
+func (c *SwervpayClient) NewRequest(ctx context.Context, method, path string, params interface{}, opts ...CallOption) (*http.Request, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+
+	settings, err := newCallSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	validate := !c.skipValidation
+	if settings.validate != nil {
+		validate = *settings.validate
+	}
+	if validate {
+		if err := validateBody(params); err != nil {
+			return nil, err
+		}
+	}
+
 	u, err := c.BaseURL.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
+	query, err := encodeQuery(settings.query)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		q := u.Query()
+		for k, values := range query {
+			q[k] = append(q[k], values...)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	ctx = context.WithValue(ctx, callSettingsKey{}, settings)
+
 	var req *http.Request
 	req, err = http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
@@ -116,18 +236,29 @@ func (c *SwervpayClient) NewRequest(ctx context.Context, method, path string, pa
 	}
 
 	req.Header.Set("Accept", contentType)
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	for k, values := range settings.headers {
+		req.Header[k] = append([]string(nil), values...)
+	}
 
-	if c.AccessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	if settings.idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, settings.idempotencyKey)
+	}
+
+	if settings.onBehalfOf != "" {
+		req.Header.Set(OnBehalfOfHeader, settings.onBehalfOf)
 	}
 
 	return req, nil
 }
 
-// Perform sends the request to the Resend API
+// Perform sends the request to the Swervpay API, retrying transient failures
+// according to the client's retry policy. An access token is obtained before
+// the first request and refreshed shortly before it expires; a request that is
+// still rejected as unauthorized is re-authenticated once.
 func (c *SwervpayClient) Perform(req *http.Request, ret interface{}) (*http.Response, error) {
-	// Store the request body for potential retry after reauthentication
+	// Store the request body so it can be replayed on retries and after reauthentication
 	var bodyBytes []byte
 	if req.Body != nil {
 		var err error
@@ -136,112 +267,272 @@ func (c *SwervpayClient) Perform(req *http.Request, ret interface{}) (*http.Resp
 			return nil, err
 		}
 		req.Body.Close()
-		// Restore the body for the first attempt
-		req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	settings := settingsFromRequest(req)
+	policy := c.retryPolicy(settings)
+	handler := c.handler(settings)
+
+	if settings.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), settings.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
 	}
+	route := c.route(req)
+	reauthenticated := false
+
+	ensureCorrelationID(req)
 
-	// Check if the status code is unauthorized
-	if resp.StatusCode == http.StatusUnauthorized {
-		// Make a request to the auth endpoint
-		authReq, authReqErr := c.NewRequest(context.Background(), http.MethodPost, "auth", nil)
-		if authReqErr != nil {
-			return nil, authReqErr
+	var (
+		last     *http.Response
+		attempts int
+	)
+	defer func(start time.Time) {
+		settings.captureResponse(last, attempts, time.Since(start))
+	}(time.Now())
+
+	for attempt := 1; ; attempt++ {
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		}
 
-		authReq.SetBasicAuth(c.Config.BusinessID, c.Config.SecretKey)
+		token, err := c.authorize(req)
+		if err != nil {
+			return nil, err
+		}
 
-		authResponse := new(AuthResponse)
+		done, err := c.breaker.allow(req.Context(), route)
+		if err != nil {
+			return nil, err
+		}
 
-		_, authReqErr = c.Perform(authReq, authResponse)
-		if authReqErr != nil {
-			return nil, authReqErr
+		release, err := c.limiter.acquire(req.Context(), route)
+		if err != nil {
+			done(nil, err)
+			return nil, err
 		}
 
-		// Update the access token
-		c.AccessToken = authResponse.AccessToken
+		start := time.Now()
+		resp, err := handler(req)
+		latency := time.Since(start)
+		release()
+		done(resp, err)
+		last, attempts = resp, attempt
 
-		// Update the original request's Authorization header
-		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+		// Re-authenticate once when the token is rejected; this does not count as a retry.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !reauthenticated && c.tokens.canRefresh() {
+			c.reportAttempt(attemptResult{req: req, reqBody: bodyBytes, resp: resp, attempt: attempt, latency: latency, retrying: true, requestID: responseRequestID(resp)})
 
-		// Restore the request body from stored bytes for retry
-		if bodyBytes != nil {
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			c.tokens.invalidate(token)
+			reauthenticated = true
+			attempt--
+			continue
 		}
 
-		// Retry the request
-		return c.Perform(req, ret)
+		delay, retry := policy.next(req, resp, err, attempt)
 
-	} else {
+		c.reportAttempt(attemptResult{req: req, reqBody: bodyBytes, resp: resp, err: err, attempt: attempt, latency: latency, retrying: retry, requestID: responseRequestID(resp)})
 
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
+		if policy != nil && policy.OnAttempt != nil {
+			info := RetryAttempt{Attempt: attempt, Method: req.Method, Path: req.URL.Path, Err: err, Retrying: retry}
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+			}
+			if retry {
+				info.Delay = delay
+			}
+			policy.OnAttempt(info)
+		}
+
+		if !retry {
 			if err != nil {
-				return
+				return nil, err
 			}
-		}(resp.Body)
 
-		// Handle possible errors.
-		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-			return nil, handleError(resp)
+			result, err := c.handleResponse(resp, ret)
+			if reauthenticated && errors.Is(err, ErrUnauthorized) {
+				return nil, fmt.Errorf("%w: request rejected after re-authentication: %w", ErrAuthentication, err)
+			}
+			return result, err
 		}
 
-		if resp.StatusCode != http.StatusNoContent && ret != nil {
-			if w, ok := ret.(io.Writer); ok {
-				_, err = io.Copy(w, resp.Body)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				if resp.Body != nil {
-					err = json.NewDecoder(resp.Body).Decode(ret)
-					if err != nil {
-						return nil, err
-					}
-				}
-			}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
 		}
 
-		return resp, err
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
 	}
 }
 
-func handleError(resp *http.Response) error {
-	switch resp.StatusCode {
+// attemptResult describes a finished request attempt.
+type attemptResult struct {
+	req       *http.Request
+	reqBody   []byte
+	resp      *http.Response
+	err       error
+	attempt   int
+	latency   time.Duration
+	retrying  bool
+	requestID string
+}
 
-	// Handles errors most likely caused by the client
-	case http.StatusUnprocessableEntity, http.StatusBadRequest:
-		r := &InvalidRequestError{}
-		err := json.NewDecoder(resp.Body).Decode(r)
-		if err != nil {
-			return err
-		}
-		return errors.New("[ERROR]: " + r.Message)
-	case http.StatusNotFound:
-		return errors.New("[ERROR]: Not Found")
-	default:
-		// Tries to parse `message` attr from error
-		r := &DefaultResponse{}
-		err := json.NewDecoder(resp.Body).Decode(r)
+// reportAttempt logs a finished attempt and records its metrics.
+func (c *SwervpayClient) reportAttempt(a attemptResult) {
+	c.logAttempt(a)
+	c.observeAttempt(a)
+}
+
+// authorize sets the Authorization header of req from the current access token,
+// which is returned so that it can be invalidated if the API rejects it.
+func (c *SwervpayClient) authorize(req *http.Request) (string, error) {
+	c.seedToken.Do(func() {
+		if c.AccessToken != "" {
+			c.tokens.seed(c.AccessToken)
+		}
+	})
+
+	token, err := c.tokens.Token(req.Context())
+	if err != nil {
+		return "", err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return token, nil
+}
+
+// route returns the templated route of req.
+func (c *SwervpayClient) route(req *http.Request) string {
+	return routeTemplate(c.BaseURL.Path, req.URL.Path)
+}
+
+// tokenStoreKey identifies the token of a business on a Swervpay environment.
+func tokenStoreKey(baseURL *url.URL, businessID string) string {
+	host := ""
+	if baseURL != nil {
+		host = baseURL.Host
+	}
+	return host + "/" + businessID
+}
+
+// authenticate exchanges the business credentials for a new access token.
+func (c *SwervpayClient) authenticate(ctx context.Context) (string, time.Time, error) {
+	req, err := c.NewRequest(ctx, http.MethodPost, "auth", nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	resp, err := c.sendAuth(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	authResponse := new(AuthResponse)
+
+	_, err = c.handleResponse(resp, authResponse)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %w", ErrAuthentication, c.checkCredentials(ctx, err))
+	}
+
+	// Credentials accepted once need no mismatch check on a later rejection.
+	c.credentialCheck.Do(func() {})
+
+	if authResponse.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("%w: no access token in response", ErrAuthentication)
+	}
+
+	return authResponse.AccessToken, authResponse.Token.ExpiresAt.Time(), nil
+}
+
+// sendAuth sends an auth request with the business credentials through the
+// circuit breaker, rate limiter and client middlewares, and reports the attempt.
+func (c *SwervpayClient) sendAuth(req *http.Request) (*http.Response, error) {
+	req.SetBasicAuth(c.Config.BusinessID, c.Config.SecretKey)
+
+	ensureCorrelationID(req)
+
+	ctx := req.Context()
+	done, err := c.breaker.allow(ctx, c.route(req))
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.limiter.acquire(ctx, c.route(req))
+	if err != nil {
+		done(nil, err)
+		return nil, err
+	}
+
+	// Auth requests go through the client's middlewares, but not those of the call that triggered them.
+	start := time.Now()
+	resp, err := c.handler(&callSettings{})(req)
+	release()
+	done(resp, err)
+	c.reportAttempt(attemptResult{req: req, resp: resp, err: err, attempt: 1, latency: time.Since(start), requestID: responseRequestID(resp)})
+	return resp, err
+}
+
+// retryPolicy returns the retry policy of the call, falling back to the client's and then to the default one.
+func (c *SwervpayClient) retryPolicy(settings *callSettings) *RetryPolicy {
+	if settings.retry != nil {
+		return settings.retry
+	}
+	if c.Config != nil && c.Config.Retry != nil {
+		return c.Config.Retry
+	}
+	return DefaultRetryPolicy()
+}
+
+// handleResponse decodes a final response into ret, or converts it to an error.
+func (c *SwervpayClient) handleResponse(resp *http.Response, ret interface{}) (*http.Response, error) {
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
 		if err != nil {
-			return err
+			return
 		}
-		if r.Message != "" {
-			return errors.New("[ERROR]: " + r.Message)
+	}(resp.Body)
+
+	// Handle possible errors.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, handleError(resp)
+	}
+
+	if resp.StatusCode != http.StatusNoContent && ret != nil {
+		if w, ok := ret.(io.Writer); ok {
+			_, err := io.Copy(w, resp.Body)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			if resp.Body != nil && c.onSchemaDrift != nil {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(body, ret); err != nil {
+					return nil, err
+				}
+				if err := c.checkSchema(resp, body, ret); err != nil {
+					return nil, err
+				}
+			} else if resp.Body != nil {
+				err := json.NewDecoder(resp.Body).Decode(ret)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
-		return errors.New("[ERROR]: Unknown Error")
 	}
-}
 
-// InvalidRequestError represents an error caused by the client.
-type InvalidRequestError struct {
-	StatusCode int    `json:"statusCode,omitempty"`
-	Name       string `json:"name,omitempty"`
-	Message    string `json:"message"`
+	return resp, nil
 }
 
 // DefaultResponse represents the default response from the Swervpay API.
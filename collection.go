@@ -7,27 +7,36 @@ import (
 
 // CollectionHistory represents the history of a collection.
 type CollectionHistory struct {
-	Amount        float64 `json:"amount"`         // The amount of the collection.
-	Charges       float64 `json:"charges"`        // The charges associated with the collection.
-	CreatedAt     string  `json:"created_at"`     // The creation date of the collection.
-	Currency      string  `json:"currency"`       // The currency of the collection.
-	ID            string  `json:"id"`             // The ID of the collection.
-	PaymentMethod string  `json:"payment_method"` // The payment method used for the collection.
-	Reference     string  `json:"reference"`      // The reference of the collection.
-	UpdatedAt     string  `json:"updated_at"`     // The last update date of the collection.
+	Amount        float64   `json:"amount"`         // The amount of the collection.
+	Charges       float64   `json:"charges"`        // The charges associated with the collection.
+	CreatedAt     Timestamp `json:"created_at"`     // The creation date of the collection.
+	Currency      Currency  `json:"currency"`       // The currency of the collection.
+	ID            string    `json:"id"`             // The ID of the collection.
+	PaymentMethod string    `json:"payment_method"` // The payment method used for the collection.
+	Reference     string    `json:"reference"`      // The reference of the collection.
+	UpdatedAt     Timestamp `json:"updated_at"`     // The last update date of the collection.
 }
 
 // CreateCollectionBody represents the body of a create collection request.
 type CreateCollectionBody struct {
 	CustomerID            string                     `json:"customer_id"`                      // The ID of the customer.
-	Currency              string                     `json:"currency"`                         // The currency of the collection.
+	Currency              Currency                   `json:"currency"`                         // The currency of the collection.
 	MerchantName          string                     `json:"merchant_name"`                    // The name of the merchant.
 	Amount                float64                    `json:"amount"`                           // The amount of the collection.
-	Type                  string                     `json:"type"`                             // The type of the collection.
+	Type                  CollectionType             `json:"type"`                             // The type of the collection.
 	Reference             string                     `json:"reference,omitempty"`              // Optional reference for idempotency.
 	AdditionalInformation *AdditionalInformationBody `json:"additional_information,omitempty"` // Optional additional information.
 }
 
+// Validate checks the collection before it is sent.
+func (b CreateCollectionBody) Validate() error {
+	v := newValidation("CreateCollectionBody")
+	v.required("customer_id", b.CustomerID)
+	v.currency("currency", b.Currency, true)
+	v.nonNegative("amount", b.Amount)
+	return v.result()
+}
+
 // AdditionalInformationBody mirrors TypesAdditionalInformation from the OpenAPI spec.
 type AdditionalInformationBody struct {
 	AccountDesignation string                     `json:"account_designation,omitempty"`
@@ -64,11 +73,11 @@ type AdditionalInformationDoc struct {
 
 // CollectionInt is an interface that defines the operations that can be performed on collections.
 type CollectionInt interface {
-	Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Wallet, error)                               // Gets a list of wallets.
-	Get(ctx context.Context, id string) (*Wallet, error)                                                 // Gets a specific wallet.
-	Create(ctx context.Context, body *CreateCollectionBody) (*Wallet, error)                             // Creates a new wallet.
-	Credit(ctx context.Context, id string, body *CreditWalletBody) (*CreditWalletResponse, error)        // Credits a collection.
-	Transactions(ctx context.Context, id string, query *PageAndLimitQuery) ([]*CollectionHistory, error) // Gets the transactions of a specific wallet.
+	Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Wallet, error)                               // Gets a list of wallets.
+	Get(ctx context.Context, id string, opts ...CallOption) (*Wallet, error)                                                 // Gets a specific wallet.
+	Create(ctx context.Context, body *CreateCollectionBody, opts ...CallOption) (*Wallet, error)                             // Creates a new wallet.
+	Credit(ctx context.Context, id string, body *CreditWalletBody, opts ...CallOption) (*CreditWalletResponse, error)        // Credits a collection.
+	Transactions(ctx context.Context, id string, query *PageAndLimitQuery, opts ...CallOption) ([]*CollectionHistory, error) // Gets the transactions of a specific wallet.
 }
 
 // CollectionIntImpl is an implementation of the CollectionInt interface.
@@ -81,10 +90,10 @@ var _ CollectionInt = &CollectionIntImpl{}
 
 // Gets retrieves a list of wallets.
 // https://docs.swervpay.co/api-reference/collections/get-all-collections
-func (c CollectionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Wallet, error) {
+func (c CollectionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Wallet, error) {
 	path := GenerateURLPath("collections", query)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +111,8 @@ func (c CollectionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) (
 
 // Get retrieves a specific wallet.
 // https://docs.swervpay.co/api-reference/collections/get
-func (c CollectionIntImpl) Get(ctx context.Context, id string) (*Wallet, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, "collections/"+id, nil)
+func (c CollectionIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Wallet, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, "collections/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +130,8 @@ func (c CollectionIntImpl) Get(ctx context.Context, id string) (*Wallet, error)
 
 // Create creates a new wallet.
 // https://docs.swervpay.co/api-reference/collections/create
-func (c CollectionIntImpl) Create(ctx context.Context, body *CreateCollectionBody) (*Wallet, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "collections", body)
+func (c CollectionIntImpl) Create(ctx context.Context, body *CreateCollectionBody, opts ...CallOption) (*Wallet, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "collections", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,10 +147,17 @@ func (c CollectionIntImpl) Create(ctx context.Context, body *CreateCollectionBod
 	return response, nil
 }
 
-// Credit credits a collection.
+// Credit credits a collection. It is a sandbox-only helper and returns ErrSandboxOnly on live.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// marked with WithRetryableCall.
 // https://docs.swervpay.co/api-reference/collections/credit
-func (c CollectionIntImpl) Credit(ctx context.Context, id string, body *CreditWalletBody) (*CreditWalletResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "collections/"+id+"/credit", body)
+func (c CollectionIntImpl) Credit(ctx context.Context, id string, body *CreditWalletBody, opts ...CallOption) (*CreditWalletResponse, error) {
+	if err := c.client.requireSandbox("Collection.Credit"); err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "collections/"+id+"/credit", body, idempotent(opts, "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,15 +170,17 @@ func (c CollectionIntImpl) Credit(ctx context.Context, id string, body *CreditWa
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
 
 // Transactions retrieves the transactions of a specific wallet.
 // https://docs.swervpay.co/api-reference/collections/transaction
-func (c CollectionIntImpl) Transactions(ctx context.Context, id string, query *PageAndLimitQuery) ([]*CollectionHistory, error) {
+func (c CollectionIntImpl) Transactions(ctx context.Context, id string, query *PageAndLimitQuery, opts ...CallOption) ([]*CollectionHistory, error) {
 	path := GenerateURLPath("collections/"+id+"/transactions", query)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
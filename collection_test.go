@@ -137,6 +137,8 @@ func TestCollectionCredit(t *testing.T) {
 	setup()
 	defer teardown()
 
+	client.environment = EnvironmentSandbox
+
 	collectionId := "coll_001"
 
 	mux.HandleFunc("/collections/"+collectionId+"/credit", func(w http.ResponseWriter, r *http.Request) {
@@ -167,7 +169,8 @@ func TestCollectionCredit(t *testing.T) {
 		},
 	}
 
-	resp, err := client.Collection.Credit(context.Background(), collectionId, req)
+	// The fixture sender is not a valid NUBAN of GTBank, so the offline check is skipped.
+	resp, err := client.Collection.Credit(context.Background(), collectionId, req, WithCallValidation(false))
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.ID, "txn_123456")
@@ -196,8 +199,8 @@ func TestCollectionTransactions(t *testing.T) {
 				Currency:      "NGN",
 				PaymentMethod: "card",
 				Reference:     "ref_001",
-				CreatedAt:     "2024-01-01T00:00:00Z",
-				UpdatedAt:     "2024-01-01T00:00:00Z",
+				CreatedAt:     MustParseTimestamp("2024-01-01T00:00:00Z"),
+				UpdatedAt:     MustParseTimestamp("2024-01-01T00:00:00Z"),
 			},
 			{
 				ID:            "hist_002",
@@ -206,8 +209,8 @@ func TestCollectionTransactions(t *testing.T) {
 				Currency:      "NGN",
 				PaymentMethod: "bank_transfer",
 				Reference:     "ref_002",
-				CreatedAt:     "2024-01-02T00:00:00Z",
-				UpdatedAt:     "2024-01-02T00:00:00Z",
+				CreatedAt:     MustParseTimestamp("2024-01-02T00:00:00Z"),
+				UpdatedAt:     MustParseTimestamp("2024-01-02T00:00:00Z"),
 			},
 		}
 		err := json.NewEncoder(w).Encode(&ret)
@@ -0,0 +1,430 @@
+package swervpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvConfigFile          = "SWERVPAY_CONFIG_FILE"           // Profile file to start from, see LoadProfile.
+	EnvProfile             = "SWERVPAY_PROFILE"               // Profile to load from EnvConfigFile.
+	EnvBusinessID          = "SWERVPAY_BUSINESS_ID"           // SwervpayClientOption.BusinessID.
+	EnvSecretKey           = "SWERVPAY_SECRET_KEY"            // SwervpayClientOption.SecretKey.
+	EnvSandbox             = "SWERVPAY_SANDBOX"               // SwervpayClientOption.Sandbox, e.g. "true".
+	EnvTimeout             = "SWERVPAY_TIMEOUT"               // SwervpayClientOption.Timeout, e.g. "30s" or "30".
+	EnvAPIVersion          = "SWERVPAY_API_VERSION"           // SwervpayClientOption.Version.
+	EnvBaseURL             = "SWERVPAY_BASE_URL"              // SwervpayClientOption.BaseURL.
+	EnvTokenFile           = "SWERVPAY_TOKEN_FILE"            // Path of a FileTokenStore.
+	EnvEnvironment         = "SWERVPAY_ENVIRONMENT"           // SwervpayClientOption.Environment, "sandbox" or "live".
+	EnvRequireExplicitLive = "SWERVPAY_REQUIRE_EXPLICIT_LIVE" // SwervpayClientOption.RequireExplicitLive.
+	EnvRetryMaxAttempts    = "SWERVPAY_RETRY_MAX_ATTEMPTS"    // RetryPolicy.MaxAttempts.
+	EnvRetryBaseBackoff    = "SWERVPAY_RETRY_BASE_BACKOFF"    // RetryPolicy.BaseBackoff, e.g. "500ms".
+	EnvRetryMaxBackoff     = "SWERVPAY_RETRY_MAX_BACKOFF"     // RetryPolicy.MaxBackoff, e.g. "10s".
+	EnvRetryJitter         = "SWERVPAY_RETRY_JITTER"          // RetryPolicy.Jitter, between 0 and 1.
+)
+
+// profileFile is the layout of a profile file:
+//
+//	default_profile: sandbox
+//	profiles:
+//	  sandbox:
+//	    business_id: biz_123
+//	    secret_key_env: SWERVPAY_SANDBOX_SECRET_KEY
+//	    sandbox: true
+//	    timeout: 30s
+//	    retry:
+//	      max_attempts: 5
+type profileFile struct {
+	DefaultProfile string                   `json:"default_profile" yaml:"default_profile"`
+	Profiles       map[string]*profileEntry `json:"profiles" yaml:"profiles"`
+}
+
+// profileEntry is a single profile. Unset values are nil or empty.
+type profileEntry struct {
+	BusinessID   string          `json:"business_id" yaml:"business_id"`
+	SecretKey    string          `json:"secret_key" yaml:"secret_key"`
+	SecretKeyEnv string          `json:"secret_key_env" yaml:"secret_key_env"` // Environment variable holding the secret key.
+	Sandbox      *bool           `json:"sandbox" yaml:"sandbox"`
+	Timeout      *configDuration `json:"timeout" yaml:"timeout"`
+	Version      string          `json:"version" yaml:"version"`
+	BaseURL      string          `json:"base_url" yaml:"base_url"`
+	TokenFile    string          `json:"token_file" yaml:"token_file"`
+	Retry        *retryEntry     `json:"retry" yaml:"retry"`
+
+	Environment         Environment `json:"environment" yaml:"environment"`
+	RequireExplicitLive *bool       `json:"require_explicit_live" yaml:"require_explicit_live"`
+}
+
+// retryEntry overrides fields of DefaultRetryPolicy.
+type retryEntry struct {
+	MaxAttempts *int            `json:"max_attempts" yaml:"max_attempts"`
+	BaseBackoff *configDuration `json:"base_backoff" yaml:"base_backoff"`
+	MaxBackoff  *configDuration `json:"max_backoff" yaml:"max_backoff"`
+	Jitter      *float64        `json:"jitter" yaml:"jitter"`
+}
+
+// configDuration is a duration written either as a Go duration string such
+// as "1m30s" or as a number of seconds.
+type configDuration time.Duration
+
+func parseConfigDuration(s string) (configDuration, error) {
+	s = strings.TrimSpace(s)
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return configDuration(seconds * float64(time.Second)), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return configDuration(d), nil
+}
+
+// UnmarshalJSON accepts a duration string or a number of seconds.
+func (d *configDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+
+	v, err := parseConfigDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalYAML accepts a duration string or a number of seconds.
+func (d *configDuration) UnmarshalYAML(node *yaml.Node) error {
+	v, err := parseConfigDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	*d = v
+	return nil
+}
+
+// LoadProfile reads the named profile from a JSON or YAML profile file,
+// chosen by its extension. When name is empty, the file's default_profile is
+// used, or the only profile when the file has exactly one. Unknown keys,
+// missing credentials and conflicting keys, such as both secret_key and
+// secret_key_env, are reported as errors matching ErrInvalidConfig.
+func LoadProfile(path, name string) (*SwervpayClientOption, error) {
+	entry, name, err := loadProfileEntry(path, name)
+	if err != nil {
+		return nil, err
+	}
+	return entry.resolve(fmt.Sprintf("profile %q", name))
+}
+
+// NewSwervpayClientFromProfile creates a client from the named profile of a
+// profile file, see LoadProfile. opts are applied after the profile.
+func NewSwervpayClientFromProfile(path, name string, opts ...Option) (*SwervpayClient, error) {
+	config, err := LoadProfile(path, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(append([]Option{WithConfig(config)}, opts...)...)
+}
+
+// ConfigFromEnv builds a client configuration from the SWERVPAY_* environment
+// variables. When SWERVPAY_CONFIG_FILE is set, the profile named by
+// SWERVPAY_PROFILE is loaded first and the other variables override it.
+func ConfigFromEnv() (*SwervpayClientOption, error) {
+	entry := &profileEntry{}
+	source := "environment"
+
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		var (
+			name string
+			err  error
+		)
+		entry, name, err = loadProfileEntry(path, os.Getenv(EnvProfile))
+		if err != nil {
+			return nil, err
+		}
+		source = fmt.Sprintf("profile %q with environment overrides", name)
+	}
+
+	if err := entry.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	return entry.resolve(source)
+}
+
+// NewSwervpayClientFromEnv creates a client configured by the environment,
+// see ConfigFromEnv. opts are applied after the environment.
+func NewSwervpayClientFromEnv(opts ...Option) (*SwervpayClient, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(append([]Option{WithConfig(config)}, opts...)...)
+}
+
+// WithConfig applies every field of config. Options given after it override
+// individual fields.
+func WithConfig(config *SwervpayClientOption) Option {
+	return func(o *clientOptions) error {
+		if config == nil {
+			return fmt.Errorf("%w: config must not be nil", ErrInvalidConfig)
+		}
+		*o.config = *config
+		return nil
+	}
+}
+
+// loadProfileEntry reads a profile file and returns the named profile and its name.
+func loadProfileEntry(path, name string) (*profileEntry, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: reading profile file: %v", ErrInvalidConfig, err)
+	}
+
+	var file profileFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	default:
+		return nil, "", fmt.Errorf("%w: profile file %s: unsupported extension %q, use .json, .yaml or .yml", ErrInvalidConfig, path, ext)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: profile file %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	if name == "" {
+		name = file.DefaultProfile
+	}
+	if name == "" && len(file.Profiles) == 1 {
+		for only := range file.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: profile file %s: no profile given and no default_profile set", ErrInvalidConfig, path)
+	}
+
+	entry, ok := file.Profiles[name]
+	if !ok || entry == nil {
+		names := make([]string, 0, len(file.Profiles))
+		for n := range file.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, "", fmt.Errorf("%w: profile file %s: no profile %q, have %s", ErrInvalidConfig, path, name, strings.Join(names, ", "))
+	}
+
+	return entry, name, nil
+}
+
+// applyEnv overrides the entry with the SWERVPAY_* environment variables that are set.
+func (p *profileEntry) applyEnv() error {
+	var errs []error
+
+	if v, ok := os.LookupEnv(EnvBusinessID); ok {
+		p.BusinessID = v
+	}
+	if v, ok := os.LookupEnv(EnvSecretKey); ok {
+		p.SecretKey = v
+		p.SecretKeyEnv = ""
+	}
+	if v, ok := os.LookupEnv(EnvSandbox); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean %q", EnvSandbox, v))
+		}
+		p.Sandbox = &b
+	}
+	if v, ok := os.LookupEnv(EnvTimeout); ok {
+		d, err := parseConfigDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", EnvTimeout, err))
+		}
+		p.Timeout = &d
+	}
+	if v, ok := os.LookupEnv(EnvAPIVersion); ok {
+		p.Version = v
+	}
+	if v, ok := os.LookupEnv(EnvBaseURL); ok {
+		p.BaseURL = v
+	}
+	if v, ok := os.LookupEnv(EnvTokenFile); ok {
+		p.TokenFile = v
+	}
+	if v, ok := os.LookupEnv(EnvEnvironment); ok {
+		p.Environment = Environment(v)
+	}
+	if v, ok := os.LookupEnv(EnvRequireExplicitLive); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean %q", EnvRequireExplicitLive, v))
+		}
+		p.RequireExplicitLive = &b
+	}
+
+	retry := p.Retry
+	if retry == nil {
+		retry = &retryEntry{}
+	}
+	set := false
+	if v, ok := os.LookupEnv(EnvRetryMaxAttempts); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid integer %q", EnvRetryMaxAttempts, v))
+		}
+		retry.MaxAttempts, set = &n, true
+	}
+	if v, ok := os.LookupEnv(EnvRetryBaseBackoff); ok {
+		d, err := parseConfigDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", EnvRetryBaseBackoff, err))
+		}
+		retry.BaseBackoff, set = &d, true
+	}
+	if v, ok := os.LookupEnv(EnvRetryMaxBackoff); ok {
+		d, err := parseConfigDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", EnvRetryMaxBackoff, err))
+		}
+		retry.MaxBackoff, set = &d, true
+	}
+	if v, ok := os.LookupEnv(EnvRetryJitter); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid number %q", EnvRetryJitter, v))
+		}
+		retry.Jitter, set = &f, true
+	}
+	if set {
+		p.Retry = retry
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: environment: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+// resolve validates the entry and converts it to a client configuration.
+// source describes where the entry came from, for error messages.
+func (p *profileEntry) resolve(source string) (*SwervpayClientOption, error) {
+	var problems []string
+
+	if p.SecretKey != "" && p.SecretKeyEnv != "" {
+		problems = append(problems, "conflicting keys secret_key and secret_key_env, set only one")
+	}
+	if p.Sandbox != nil && *p.Sandbox && p.Environment == EnvironmentLive {
+		problems = append(problems, "conflicting keys sandbox and environment, set only environment")
+	}
+	if p.Environment != "" && p.Environment != EnvironmentSandbox && p.Environment != EnvironmentLive {
+		problems = append(problems, fmt.Sprintf("environment %q must be %q or %q", p.Environment, EnvironmentSandbox, EnvironmentLive))
+	}
+
+	secretKey := p.SecretKey
+	if p.SecretKeyEnv != "" && p.SecretKey == "" {
+		secretKey = os.Getenv(p.SecretKeyEnv)
+		if secretKey == "" {
+			problems = append(problems, fmt.Sprintf("secret_key_env names %s, which is not set", p.SecretKeyEnv))
+		}
+	}
+
+	var missing []string
+	if p.BusinessID == "" {
+		missing = append(missing, "business_id ("+EnvBusinessID+")")
+	}
+	if secretKey == "" && p.SecretKeyEnv == "" {
+		missing = append(missing, "secret_key ("+EnvSecretKey+")")
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+
+	config := &SwervpayClientOption{
+		BusinessID:  p.BusinessID,
+		SecretKey:   secretKey,
+		Version:     p.Version,
+		BaseURL:     p.BaseURL,
+		Environment: p.Environment,
+	}
+
+	if p.Sandbox != nil {
+		config.Sandbox = *p.Sandbox
+	}
+	if p.RequireExplicitLive != nil {
+		config.RequireExplicitLive = *p.RequireExplicitLive
+	}
+
+	if p.Timeout != nil {
+		timeout := time.Duration(*p.Timeout)
+		switch {
+		case timeout < 0:
+			problems = append(problems, "timeout must not be negative")
+		case timeout%time.Second != 0:
+			problems = append(problems, fmt.Sprintf("timeout %s must be a whole number of seconds", timeout))
+		default:
+			config.Timeout = int(timeout / time.Second)
+		}
+	}
+
+	if p.BaseURL != "" {
+		if _, err := parseBaseURL(p.BaseURL); err != nil {
+			problems = append(problems, strings.TrimPrefix(err.Error(), ErrInvalidConfig.Error()+": "))
+		}
+	}
+
+	if p.TokenFile != "" {
+		config.TokenStore = NewFileTokenStore(p.TokenFile)
+	}
+
+	if p.Retry != nil {
+		r, policy := p.Retry, DefaultRetryPolicy()
+		if r.MaxAttempts != nil {
+			policy.MaxAttempts = *r.MaxAttempts
+		}
+		if r.BaseBackoff != nil {
+			policy.BaseBackoff = time.Duration(*r.BaseBackoff)
+		}
+		if r.MaxBackoff != nil {
+			policy.MaxBackoff = time.Duration(*r.MaxBackoff)
+		}
+		if r.Jitter != nil {
+			policy.Jitter = *r.Jitter
+		}
+
+		if policy.MaxAttempts < 1 {
+			problems = append(problems, "retry.max_attempts must be at least 1")
+		}
+		if policy.BaseBackoff < 0 || policy.MaxBackoff < 0 {
+			problems = append(problems, "retry backoffs must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			problems = append(problems, "retry.jitter must be between 0 and 1")
+		}
+		config.Retry = policy
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidConfig, source, strings.Join(problems, "; "))
+	}
+
+	return config, nil
+}
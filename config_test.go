@@ -0,0 +1,152 @@
+package swervpay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProfileFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const testProfilesYAML = `
+default_profile: sandbox
+profiles:
+  sandbox:
+    business_id: biz_sandbox
+    secret_key_env: TEST_SWERVPAY_SANDBOX_SECRET
+    sandbox: true
+    timeout: 30s
+    version: "v1"
+    retry:
+      max_attempts: 5
+      base_backoff: 250ms
+  live:
+    business_id: biz_live
+    secret_key: sk_live
+    base_url: https://proxy.example.com/swervpay
+    timeout: 10
+`
+
+func TestLoadProfileYAML(t *testing.T) {
+	t.Setenv("TEST_SWERVPAY_SANDBOX_SECRET", "sk_sandbox")
+	path := writeProfileFile(t, "swervpay.yaml", testProfilesYAML)
+
+	config, err := LoadProfile(path, "")
+	assert.NoError(t, err)
+	assert.Equal(t, config.BusinessID, "biz_sandbox")
+	assert.Equal(t, config.SecretKey, "sk_sandbox")
+	assert.True(t, config.Sandbox)
+	assert.Equal(t, config.Timeout, 30)
+	assert.Equal(t, config.Version, "v1")
+	if assert.NotNil(t, config.Retry) {
+		assert.Equal(t, config.Retry.MaxAttempts, 5)
+		assert.Equal(t, config.Retry.BaseBackoff, 250*time.Millisecond)
+		assert.Equal(t, config.Retry.MaxBackoff, DefaultRetryPolicy().MaxBackoff)
+	}
+
+	config, err = LoadProfile(path, "live")
+	assert.NoError(t, err)
+	assert.Equal(t, config.SecretKey, "sk_live")
+	assert.Equal(t, config.BaseURL, "https://proxy.example.com/swervpay")
+	assert.Equal(t, config.Timeout, 10)
+	assert.Nil(t, config.Retry)
+
+	_, err = LoadProfile(path, "staging")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), `no profile "staging", have live, sandbox`)
+}
+
+func TestLoadProfileJSON(t *testing.T) {
+	path := writeProfileFile(t, "swervpay.json", `{
+		"profiles": {
+			"live": {"business_id": "biz_live", "secret_key": "sk_live", "token_file": "/tmp/tokens.json", "retry": {"jitter": 0}}
+		}
+	}`)
+
+	c, err := NewSwervpayClientFromProfile(path, "")
+	assert.NoError(t, err)
+	assert.Equal(t, c.Config.BusinessID, "biz_live")
+	assert.Equal(t, c.BaseURL.String(), liveBaseURL)
+	assert.IsType(t, &FileTokenStore{}, c.Config.TokenStore)
+	assert.Equal(t, c.Config.Retry.Jitter, 0.0)
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		message string
+	}{
+		{"unknown key", "p.yaml", "profiles:\n  live:\n    business_id: biz\n    secret: sk\n", "field secret not found"},
+		{"missing keys", "p.yaml", "profiles:\n  live:\n    sandbox: false\n", "missing business_id (SWERVPAY_BUSINESS_ID), secret_key (SWERVPAY_SECRET_KEY)"},
+		{"conflicting secret", "p.json", `{"profiles":{"live":{"business_id":"biz","secret_key":"sk","secret_key_env":"SK"}}}`, "conflicting keys secret_key and secret_key_env"},
+		{"conflicting environment", "p.json", `{"profiles":{"live":{"business_id":"biz","secret_key":"sk","sandbox":true,"environment":"live"}}}`, "conflicting keys sandbox and environment"},
+		{"unknown environment", "p.json", `{"profiles":{"live":{"business_id":"biz","secret_key":"sk","environment":"prod"}}}`, `environment "prod" must be "sandbox" or "live"`},
+		{"unset secret env", "p.json", `{"profiles":{"live":{"business_id":"biz","secret_key_env":"TEST_SWERVPAY_UNSET"}}}`, "TEST_SWERVPAY_UNSET, which is not set"},
+		{"fractional timeout", "p.json", `{"profiles":{"live":{"business_id":"biz","secret_key":"sk","timeout":"1500ms"}}}`, "whole number of seconds"},
+		{"bad duration", "p.yaml", "profiles:\n  live:\n    timeout: soon\n", `invalid duration "soon"`},
+		{"no default", "p.json", `{"profiles":{"a":{},"b":{}}}`, "no default_profile set"},
+		{"extension", "p.toml", "", `unsupported extension ".toml"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadProfile(writeProfileFile(t, tc.file, tc.content), "")
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.ErrorContains(t, err, tc.message)
+		})
+	}
+}
+
+func TestNewSwervpayClientFromEnv(t *testing.T) {
+	t.Setenv(EnvBusinessID, "biz_001")
+	t.Setenv(EnvSecretKey, "sk_001")
+	t.Setenv(EnvSandbox, "true")
+	t.Setenv(EnvTimeout, "15s")
+	t.Setenv(EnvRetryMaxAttempts, "2")
+
+	c, err := NewSwervpayClientFromEnv(WithAPIVersion("v1"))
+	assert.NoError(t, err)
+	assert.Equal(t, c.Config.BusinessID, "biz_001")
+	assert.Equal(t, c.BaseURL.String(), sandboxBaseURL)
+	assert.Equal(t, c.client.Timeout, 15*time.Second)
+	assert.Equal(t, c.Config.Retry.MaxAttempts, 2)
+	assert.Equal(t, c.Config.Version, "v1")
+}
+
+func TestConfigFromEnvOverridesProfile(t *testing.T) {
+	t.Setenv("TEST_SWERVPAY_SANDBOX_SECRET", "sk_sandbox")
+	t.Setenv(EnvConfigFile, writeProfileFile(t, "swervpay.yml", testProfilesYAML))
+	t.Setenv(EnvProfile, "sandbox")
+	t.Setenv(EnvBusinessID, "biz_override")
+
+	config, err := ConfigFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, config.BusinessID, "biz_override")
+	assert.Equal(t, config.SecretKey, "sk_sandbox")
+	assert.Equal(t, config.Timeout, 30)
+}
+
+func TestConfigFromEnvErrors(t *testing.T) {
+	t.Setenv(EnvBusinessID, "")
+	t.Setenv(EnvSecretKey, "")
+
+	_, err := ConfigFromEnv()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.ErrorContains(t, err, "environment: missing business_id (SWERVPAY_BUSINESS_ID), secret_key (SWERVPAY_SECRET_KEY)")
+
+	t.Setenv(EnvBusinessID, "biz_001")
+	t.Setenv(EnvSecretKey, "sk_001")
+	t.Setenv(EnvSandbox, "maybe")
+	_, err = ConfigFromEnv()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.ErrorContains(t, err, `SWERVPAY_SANDBOX: invalid boolean "maybe"`)
+}
@@ -0,0 +1,135 @@
+package swervpay
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code, such as "NGN".
+type Currency string
+
+const (
+	NGN Currency = "NGN" // Nigerian naira.
+	USD Currency = "USD" // US dollar.
+)
+
+// ErrUnsupportedCurrency is returned when a request body names a currency
+// that Swervpay does not support.
+var ErrUnsupportedCurrency = errors.New("swervpay: unsupported currency")
+
+// CurrencyInfo describes a currency of the registry.
+type CurrencyInfo struct {
+	Code       Currency `json:"code"`        // ISO 4217 alphabetic code, e.g. "NGN".
+	Numeric    string   `json:"numeric"`     // ISO 4217 numeric code, e.g. "566".
+	Name       string   `json:"name"`        // English name, e.g. "Nigerian Naira".
+	MinorUnits int      `json:"minor_units"` // Number of decimal places, e.g. 2 for kobo.
+	Symbol     string   `json:"symbol"`      // Display symbol, e.g. "₦".
+}
+
+//go:embed data/currencies.json
+var currencyData []byte
+
+// currencies is the registry of the currencies Swervpay supports, by code.
+var currencies = loadCurrencies(currencyData)
+
+func loadCurrencies(data []byte) map[Currency]CurrencyInfo {
+	var list []CurrencyInfo
+	if err := json.Unmarshal(data, &list); err != nil {
+		panic(fmt.Sprintf("swervpay: invalid currency registry: %v", err))
+	}
+
+	m := make(map[Currency]CurrencyInfo, len(list))
+	for _, info := range list {
+		m[info.Code] = info
+	}
+	return m
+}
+
+// Currencies returns the currencies Swervpay supports, sorted by code.
+func Currencies() []CurrencyInfo {
+	list := make([]CurrencyInfo, 0, len(currencies))
+	for _, info := range currencies {
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// ParseCurrency returns the supported currency with code s, ignoring case
+// and surrounding spaces.
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	return c, nil
+}
+
+// Info returns the registry entry of c, if Swervpay supports it.
+func (c Currency) Info() (CurrencyInfo, bool) {
+	info, ok := currencies[c]
+	return info, ok
+}
+
+// IsSupported reports whether Swervpay supports c.
+func (c Currency) IsSupported() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Validate returns an error wrapping ErrUnsupportedCurrency unless Swervpay supports c.
+func (c Currency) Validate() error {
+	if !c.IsSupported() {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, string(c))
+	}
+	return nil
+}
+
+// MinorUnits returns the number of decimal places of c, or 2 for a currency
+// outside the registry.
+func (c Currency) MinorUnits() int {
+	if info, ok := currencies[c]; ok {
+		return info.MinorUnits
+	}
+	return 2
+}
+
+// Symbol returns the display symbol of c, or its code for a currency outside the registry.
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok && info.Symbol != "" {
+		return info.Symbol
+	}
+	return string(c)
+}
+
+// Format formats a for display in c, rounded to the currency's minor units
+// and with thousands separators, e.g. "₦1,250.00" or "-$12.50". Currencies
+// outside the registry are written with their code, e.g. "EUR 3.00".
+func (c Currency) Format(a Amount) string {
+	rounded := a.Round(c.MinorUnits())
+	intPart, fracPart, hasFrac := strings.Cut(rounded.Abs().String(), ".")
+
+	var b strings.Builder
+	if rounded.Sign() < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(c.Symbol())
+	if c.Symbol() == string(c) {
+		b.WriteByte(' ')
+	}
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
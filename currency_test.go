@@ -0,0 +1,94 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	info, ok := NGN.Info()
+	assert.True(t, ok)
+	assert.Equal(t, CurrencyInfo{Code: NGN, Numeric: "566", Name: "Nigerian Naira", MinorUnits: 2, Symbol: "₦"}, info)
+
+	assert.Equal(t, "$", USD.Symbol())
+	assert.Equal(t, 2, USD.MinorUnits())
+	assert.Equal(t, "EUR", Currency("EUR").Symbol())
+
+	codes := []Currency{}
+	for _, c := range Currencies() {
+		codes = append(codes, c.Code)
+	}
+	assert.Equal(t, []Currency{NGN, USD}, codes)
+
+	c, err := ParseCurrency(" ngn ")
+	assert.NoError(t, err)
+	assert.Equal(t, NGN, c)
+
+	_, err = ParseCurrency("NGm")
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+	assert.ErrorIs(t, Currency("EUR").Validate(), ErrUnsupportedCurrency)
+	assert.NoError(t, USD.Validate())
+}
+
+func TestCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		amount   string
+		want     string
+	}{
+		{NGN, "1250", "₦1,250.00"},
+		{USD, "12.5", "$12.50"},
+		{NGN, "1234567.891", "₦1,234,567.89"},
+		{USD, "-0.005", "-$0.01"},
+		{USD, "-0.004", "$0.00"},
+		{NGN, "999.999", "₦1,000.00"},
+		{Currency("EUR"), "3", "EUR 3.00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.currency.Format(MustParseAmount(tt.amount)), tt.amount)
+	}
+
+	m := NewMoney(MustParseAmount("1250.5"), NGN)
+	assert.Equal(t, "₦1,250.50", m.Format())
+
+	kobo, err := m.MinorUnits()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(125050), kobo)
+}
+
+func TestRequestBodyCurrencyValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	called := false
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx := context.Background()
+
+	_, err := client.Payout.Create(ctx, &CreatePayoutBody{Currency: "NGm", Amount: 100})
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+	assert.Contains(t, err.Error(), `currency "NGm"`)
+
+	_, err = client.Fx.Rate(ctx, FxBody{Amount: 1, From: USD, To: "ngn"})
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+	assert.Contains(t, err.Error(), `to "ngn"`)
+
+	_, err = client.Collection.Create(ctx, &CreateCollectionBody{Currency: "EUR"})
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+
+	_, err = client.Card.Create(ctx, &CreateCardBody{Currency: "GBP"})
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+
+	assert.False(t, called)
+
+	var body *CreatePayoutBody
+	_, err = client.NewRequest(ctx, http.MethodPost, "payouts", body)
+	assert.NoError(t, err)
+}
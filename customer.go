@@ -2,22 +2,52 @@ package swervpay
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
 // Customer represents a customer in the Swervpay system.
 type Customer struct {
-	Country       string `json:"country"`        // The country of the customer.
-	CreatedAt     string `json:"created_at"`     // The creation date of the customer.
-	Email         string `json:"email"`          // The email of the customer.
-	FirstName     string `json:"first_name"`     // The first name of the customer.
-	ID            string `json:"id"`             // The ID of the customer.
-	IsBlacklisted bool   `json:"is_blacklisted"` // Whether the customer is blacklisted.
-	LastName      string `json:"last_name"`      // The last name of the customer.
-	MiddleName    string `json:"middle_name"`    // The middle name of the customer.
-	PhoneNumber   string `json:"phone_number"`   // The phone number of the customer.
-	Status        string `json:"status"`         // The status of the customer.
-	UpdatedAt     string `json:"updated_at"`     // The last update date of the customer.
+	Country       string         `json:"country"`        // The country of the customer.
+	CreatedAt     Timestamp      `json:"created_at"`     // The creation date of the customer.
+	Email         string         `json:"email"`          // The email of the customer.
+	FirstName     string         `json:"first_name"`     // The first name of the customer.
+	ID            string         `json:"id"`             // The ID of the customer.
+	IsBlacklisted bool           `json:"is_blacklisted"` // Whether the customer is blacklisted.
+	LastName      string         `json:"last_name"`      // The last name of the customer.
+	MiddleName    string         `json:"middle_name"`    // The middle name of the customer.
+	PhoneNumber   string         `json:"phone_number"`   // The phone number of the customer.
+	Status        CustomerStatus `json:"status"`         // The status of the customer.
+	UpdatedAt     Timestamp      `json:"updated_at"`     // The last update date of the customer.
+
+	Extra map[string]json.RawMessage `json:"-"` // Fields returned by the API that the model does not have yet
+}
+
+// UnmarshalJSON decodes a customer, keeping unknown fields in Extra.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	if err := json.Unmarshal(data, (*customer)(c)); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, (*customer)(c))
+	if err != nil {
+		return err
+	}
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes a customer, including the fields kept in Extra.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+	return marshalWithExtra(customer(c), c.Extra)
+}
+
+func (c *Customer) extraFields() map[string]json.RawMessage {
+	return c.Extra
 }
 
 // CreateCustomerBody represents the body of a request to create a new customer.
@@ -29,19 +59,57 @@ type CreateCustomerBody struct {
 	Middlename string `json:"middlename"` // The middle name of the new customer.
 }
 
+// Validate checks the customer before it is sent.
+func (b CreateCustomerBody) Validate() error {
+	v := newValidation("CreateCustomerBody")
+	v.required("firstname", b.Firstname)
+	v.required("lastname", b.Lastname)
+	if v.required("email", b.Email) {
+		v.email("email", b.Email)
+	}
+	if v.required("country", b.Country) {
+		v.format("country", b.Country, countryCode)
+	}
+	return v.result()
+}
+
 // UpdateustomerBody represents the body of a request to update a customer.
 type UpdateustomerBody struct {
 	Email       string `json:"email"`        // The new email of the customer.
 	PhoneNumber string `json:"phone_number"` // The new phone number of the customer.
 }
 
+// Validate checks the update before it is sent.
+func (b UpdateustomerBody) Validate() error {
+	v := newValidation("UpdateustomerBody")
+	v.email("email", b.Email)
+	return v.result()
+}
+
 // CustomerKycBody represents the body of a request to update a customer's KYC information.
+// Build it with NewTier1KycBody or NewTier2KycBody to fill in only what the tier needs.
 type CustomerKycBody struct {
-	Tier  string        `json:"tier"`        // The tier of the KYC information.
+	Tier  KycTier       `json:"tier"`        // The tier of the KYC information.
 	Tier1 Tier1KycInput `json:"information"` // The tier 1 KYC information.
 	Tier2 Tier2KycInput `json:"document"`    // The tier 2 KYC information.
 }
 
+// Validate checks that the information the tier needs is present and well formed.
+func (b CustomerKycBody) Validate() error {
+	v := newValidation("CustomerKycBody")
+	switch b.Tier {
+	case KycTier1:
+		v.nested("information.", b.Tier1.Validate())
+	case KycTier2:
+		v.nested("document.", b.Tier2.Validate())
+	case "":
+		v.fail("tier", "is required")
+	default:
+		v.fail("tier", fmt.Sprintf("must be %q or %q, not %q", KycTier1, KycTier2, b.Tier))
+	}
+	return v.result()
+}
+
 // Tier1KycInput represents the tier 1 KYC information of a customer.
 type Tier1KycInput struct {
 	Bvn         string `json:"bvn"`          // The BVN of the customer.
@@ -53,22 +121,58 @@ type Tier1KycInput struct {
 	PhoneNumber string `json:"phone_number"` // The phone number of the customer.
 }
 
+// Validate checks the tier 1 information: an 11-digit BVN, an E.164 phone
+// number, an ISO 3166-1 alpha-2 country and the address.
+func (i Tier1KycInput) Validate() error {
+	v := newValidation("Tier1KycInput")
+	if v.required("bvn", i.Bvn) {
+		v.format("bvn", i.Bvn, elevenDigits)
+	}
+	if v.required("phone_number", i.PhoneNumber) {
+		v.format("phone_number", i.PhoneNumber, e164)
+	}
+	v.required("address", i.Address)
+	v.required("city", i.City)
+	v.required("state", i.State)
+	if v.required("country", i.Country) {
+		v.format("country", i.Country, countryCode)
+	}
+	return v.result()
+}
+
 // Tier2KycInput represents the tier 2 KYC information of a customer.
 type Tier2KycInput struct {
-	DocumentType   string `json:"document_type"`   // The type of the document.
-	Document       string `json:"document"`        // The document.
-	Passport       string `json:"passport"`        // The passport of the customer.
-	DocumentNumber string `json:"document_number"` // The document number.
+	DocumentType   KycDocumentType `json:"document_type"`   // The type of the document.
+	Document       string          `json:"document"`        // The document.
+	Passport       string          `json:"passport"`        // The passport of the customer.
+	DocumentNumber string          `json:"document_number"` // The document number.
+}
+
+// Validate checks the tier 2 document: a known type, its number, 11 digits
+// for a NIN, and the document itself.
+func (i Tier2KycInput) Validate() error {
+	v := newValidation("Tier2KycInput")
+	if i.DocumentType == "" {
+		v.fail("document_type", "is required")
+	} else if !i.DocumentType.IsKnown() {
+		v.fail("document_type", fmt.Sprintf("%q is not one of %s, %s, %s or %s", i.DocumentType,
+			KycDocumentNIN, KycDocumentPassport, KycDocumentDriversLicense, KycDocumentVotersCard))
+	}
+	if v.required("document_number", i.DocumentNumber) && oneOf(i.DocumentType, KycDocumentNIN) {
+		v.format("document_number", i.DocumentNumber, elevenDigits)
+	}
+	v.required("document", i.Document)
+	return v.result()
 }
 
 // CustomerInt is an interface that defines the methods for interacting with customers in the Swervpay system.
 type CustomerInt interface {
-	Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Customer, error)             // Gets a list of customers.
-	Get(ctx context.Context, id string) (*Customer, error)                               // Gets a specific customer.
-	Create(ctx context.Context, body *CreateCustomerBody) (*Customer, error)             // Creates a new customer.
-	Update(ctx context.Context, id string, body *UpdateustomerBody) (*Customer, error)   // Updates a specific customer.
-	Kyc(ctx context.Context, id string, body *CustomerKycBody) (*DefaultResponse, error) // Updates the KYC information of a specific customer.
-	Blacklist(ctx context.Context, id string) (*DefaultResponse, error)                  // Blacklists a specific customer.
+	Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Customer, error)             // Gets a list of customers.
+	Get(ctx context.Context, id string, opts ...CallOption) (*Customer, error)                               // Gets a specific customer.
+	Create(ctx context.Context, body *CreateCustomerBody, opts ...CallOption) (*Customer, error)             // Creates a new customer.
+	Update(ctx context.Context, id string, body *UpdateustomerBody, opts ...CallOption) (*Customer, error)   // Updates a specific customer.
+	Kyc(ctx context.Context, id string, body *CustomerKycBody, opts ...CallOption) (*DefaultResponse, error) // Updates the KYC information of a specific customer.
+	Blacklist(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)                  // Blacklists a specific customer.
 }
 
 // CustomerIntImpl is an implementation of the CustomerInt interface.
@@ -81,10 +185,10 @@ var _ CustomerInt = &CustomerIntImpl{}
 
 // Gets retrieves a list of customers.
 // https://docs.swervpay.co/api-reference/customers/get-all-customers
-func (c CustomerIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Customer, error) {
+func (c CustomerIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Customer, error) {
 	path := GenerateURLPath("customers", query)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +206,8 @@ func (c CustomerIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]
 
 // Get retrieves a specific customer.
 // https://docs.swervpay.co/api-reference/customers/get
-func (c CustomerIntImpl) Get(ctx context.Context, id string) (*Customer, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, "customers/"+id, nil)
+func (c CustomerIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Customer, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodGet, "customers/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +225,8 @@ func (c CustomerIntImpl) Get(ctx context.Context, id string) (*Customer, error)
 
 // Create creates a new customer.
 // https://docs.swervpay.co/api-reference/customers/create
-func (c CustomerIntImpl) Create(ctx context.Context, body *CreateCustomerBody) (*Customer, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers", body)
+func (c CustomerIntImpl) Create(ctx context.Context, body *CreateCustomerBody, opts ...CallOption) (*Customer, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,8 +244,8 @@ func (c CustomerIntImpl) Create(ctx context.Context, body *CreateCustomerBody) (
 
 // Update updates a specific customer.
 // https://docs.swervpay.co/api-reference/customers/update
-func (c CustomerIntImpl) Update(ctx context.Context, id string, body *UpdateustomerBody) (*Customer, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/update", body)
+func (c CustomerIntImpl) Update(ctx context.Context, id string, body *UpdateustomerBody, opts ...CallOption) (*Customer, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/update", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,8 +263,8 @@ func (c CustomerIntImpl) Update(ctx context.Context, id string, body *Updateusto
 
 // Kyc updates the KYC information of a specific customer.
 // https://docs.swervpay.co/api-reference/customers/kyc
-func (c CustomerIntImpl) Kyc(ctx context.Context, id string, body *CustomerKycBody) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/kyc", body)
+func (c CustomerIntImpl) Kyc(ctx context.Context, id string, body *CustomerKycBody, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/kyc", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,8 +282,8 @@ func (c CustomerIntImpl) Kyc(ctx context.Context, id string, body *CustomerKycBo
 
 // Blacklist blacklists a specific customer.
 // https://docs.swervpay.co/api-reference/customers/blacklist
-func (c CustomerIntImpl) Blacklist(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/blacklist", nil)
+func (c CustomerIntImpl) Blacklist(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := c.client.NewRequest(ctx, http.MethodPost, "customers/"+id+"/blacklist", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
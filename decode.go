@@ -0,0 +1,274 @@
+package swervpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrSchemaDrift is matched through errors.Is by *SchemaDriftError.
+var ErrSchemaDrift = errors.New("swervpay: response does not match model")
+
+// SchemaDrift describes the differences between a response body and the
+// model it was decoded into.
+type SchemaDrift struct {
+	Type    string   // Go type the response was decoded into, e.g. "[]*swervpay.Card".
+	Route   string   // Templated route of the request, e.g. "cards/{id}".
+	Unknown []string // Paths of fields in the response that the model does not have, e.g. "wallet.iban".
+	Missing []string // Paths of model fields, not marked omitempty, that the response lacks.
+}
+
+// SchemaDriftError is returned by FailOnSchemaDrift.
+type SchemaDriftError struct {
+	Drift SchemaDrift
+}
+
+// Error implements the error interface.
+func (e *SchemaDriftError) Error() string {
+	var parts []string
+	if len(e.Drift.Unknown) > 0 {
+		parts = append(parts, "unknown fields "+strings.Join(e.Drift.Unknown, ", "))
+	}
+	if len(e.Drift.Missing) > 0 {
+		parts = append(parts, "missing fields "+strings.Join(e.Drift.Missing, ", "))
+	}
+	return fmt.Sprintf("swervpay: response of %s does not match %s: %s", e.Drift.Route, e.Drift.Type, strings.Join(parts, "; "))
+}
+
+// Is reports whether target is ErrSchemaDrift.
+func (e *SchemaDriftError) Is(target error) bool {
+	return target == ErrSchemaDrift
+}
+
+// FailOnSchemaDrift is a drift handler for WithStrictDecoding that fails
+// the call with a *SchemaDriftError. It is meant for tests.
+func FailOnSchemaDrift(drift SchemaDrift) error {
+	return &SchemaDriftError{Drift: drift}
+}
+
+// WithStrictDecoding compares every successful response with the model it
+// is decoded into and calls onDrift when fields are unknown or missing. The
+// call fails with the error onDrift returns, if any; return nil to only
+// report the drift, or use FailOnSchemaDrift to fail.
+func WithStrictDecoding(onDrift func(SchemaDrift) error) Option {
+	return func(o *clientOptions) error {
+		o.onSchemaDrift = onDrift
+		return nil
+	}
+}
+
+// checkSchema reports drift between the body of resp and ret to the client's drift handler.
+func (c *SwervpayClient) checkSchema(resp *http.Response, body []byte, ret interface{}) error {
+	t := reflect.TypeOf(ret)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	unknown, missing, err := schemaDrift(body, t)
+	if err != nil || (len(unknown) == 0 && len(missing) == 0) {
+		return nil
+	}
+
+	drift := SchemaDrift{Type: t.String(), Unknown: unknown, Missing: missing}
+	if resp.Request != nil {
+		drift.Route = c.route(resp.Request)
+	}
+
+	return c.onSchemaDrift(drift)
+}
+
+// schemaDrift returns the paths of unknown and missing fields of body relative to t.
+func schemaDrift(body []byte, t reflect.Type) ([]string, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+
+	w := &driftWalker{unknown: map[string]bool{}, missing: map[string]bool{}}
+	w.walk("", v, t)
+
+	return sortedKeys(w.unknown), sortedKeys(w.missing), nil
+}
+
+type driftWalker struct {
+	unknown map[string]bool
+	missing map[string]bool
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	extraHolderType = reflect.TypeOf((*extraHolder)(nil)).Elem()
+)
+
+func (w *driftWalker) walk(path string, v interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types with their own decoding are opaque, unless they only keep extra fields.
+	if reflect.PointerTo(t).Implements(unmarshalerType) && !reflect.PointerTo(t).Implements(extraHolderType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		fields := jsonFields(t)
+		seen := make(map[string]bool, len(obj))
+		for key, value := range obj {
+			f, ok := matchField(fields, key)
+			if !ok {
+				w.unknown[joinPath(path, key)] = true
+				continue
+			}
+			seen[f.name] = true
+			w.walk(joinPath(path, f.name), value, t.Field(f.index).Type)
+		}
+
+		for _, f := range fields {
+			if !seen[f.name] && !f.omitempty {
+				w.missing[joinPath(path, f.name)] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := v.([]interface{})
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			w.walk(path+"[]", item, t.Elem())
+		}
+	}
+}
+
+// jsonField is an exported struct field as seen by encoding/json.
+type jsonField struct {
+	name      string
+	index     int
+	omitempty bool
+}
+
+var jsonFieldCache sync.Map // reflect.Type -> []jsonField
+
+// jsonFields returns the JSON fields of the struct type t.
+func jsonFields(t reflect.Type) []jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.([]jsonField)
+	}
+
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields = append(fields, jsonField{name: name, index: i, omitempty: strings.Contains(opts, "omitempty")})
+	}
+
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
+
+// matchField finds the field a JSON key decodes into, preferring an exact
+// match and falling back to the case-insensitive match encoding/json uses.
+func matchField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// extraHolder is implemented by models that keep unknown fields in Extra.
+type extraHolder interface {
+	extraFields() map[string]json.RawMessage
+}
+
+// unknownFields returns the members of the JSON object data that do not
+// decode into a field of v, which must be a pointer to a struct.
+func unknownFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+
+	var extra map[string]json.RawMessage
+	for key, value := range obj {
+		if _, ok := matchField(fields, key); ok {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[key] = value
+	}
+	return extra, nil
+}
+
+// marshalWithExtra marshals v and adds the members of extra that v does not already have.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := obj[key]; !ok {
+			obj[key] = value
+		}
+	}
+	return json.Marshal(obj)
+}
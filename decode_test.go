@@ -0,0 +1,95 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelsKeepUnknownFields(t *testing.T) {
+	var txn Transaction
+	err := json.Unmarshal([]byte(`{"id":"txn_001","amount":100,"fee_bearer":"customer","wallet":{"id":"wal_001","iban":"NG00"}}`), &txn)
+	assert.NoError(t, err)
+	assert.Equal(t, txn.ID, "txn_001")
+	assert.Equal(t, txn.Extra, map[string]json.RawMessage{"fee_bearer": json.RawMessage(`"customer"`)})
+	assert.Equal(t, txn.Wallet.Extra, map[string]json.RawMessage{"iban": json.RawMessage(`"NG00"`)})
+
+	data, err := json.Marshal(txn)
+	assert.NoError(t, err)
+
+	var out map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, out["fee_bearer"], "customer")
+	assert.Equal(t, out["wallet"].(map[string]interface{})["iban"], "NG00")
+
+	var card Card
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"card_001"}`), &card))
+	assert.Nil(t, card.Extra)
+}
+
+func TestSchemaDrift(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Note string `json:"note,omitempty"`
+	}
+	type model struct {
+		Name  string  `json:"name"`
+		Items []item  `json:"items"`
+		Card  *Card   `json:"card,omitempty"`
+		Skip  string  `json:"-"`
+		Total float64 `json:"total"`
+	}
+
+	unknown, missing, err := schemaDrift([]byte(`{"name":"a","items":[{"id":"1","extra":true},{"colour":"red"}],"card":{"id":"card_001","pin":"0000"},"new":1}`), reflect.TypeOf(model{}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"card.pin", "items[].colour", "items[].extra", "new"}, unknown)
+	assert.Contains(t, missing, "total")
+	assert.Contains(t, missing, "items[].id")
+	assert.Contains(t, missing, "card.cvv")
+	assert.NotContains(t, missing, "items[].note")
+}
+
+func TestStrictDecodingReportsDrift(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var drifts []SchemaDrift
+	client.onSchemaDrift = func(d SchemaDrift) error {
+		drifts = append(drifts, d)
+		return nil
+	}
+
+	mux.HandleFunc("/customers/cus_001", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"cus_001","first_name":"Ada","tier":2}`))
+	})
+
+	customer, err := client.Customer.Get(context.Background(), "cus_001")
+	assert.NoError(t, err)
+	assert.Equal(t, customer.FirstName, "Ada")
+
+	if assert.Len(t, drifts, 1) {
+		assert.Equal(t, drifts[0].Type, "swervpay.Customer")
+		assert.Equal(t, drifts[0].Route, "customers/{id}")
+		assert.Equal(t, drifts[0].Unknown, []string{"tier"})
+		assert.Contains(t, drifts[0].Missing, "email")
+	}
+}
+
+func TestStrictDecodingFailsCall(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.onSchemaDrift = FailOnSchemaDrift
+
+	mux.HandleFunc("/banks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"bank_name":"Access Bank","bank_code":"044","nip_code":"000014"}]`))
+	})
+
+	_, err := client.Other.Banks(context.Background())
+	assert.ErrorIs(t, err, ErrSchemaDrift)
+	assert.ErrorContains(t, err, "unknown fields [].nip_code")
+}
@@ -0,0 +1,165 @@
+package swervpay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Do calls an endpoint of the Swervpay API that the SDK does not wrap yet and
+// decodes the response into a new T. The call goes through the same
+// authentication, retries, middlewares, logging and error handling as the
+// built-in resources.
+//
+// path is relative to the client's base URL, e.g. "cards/" + url.PathEscape(id)
+// or EscapePath("cards", id, "fund"). body, when not nil, is sent as JSON;
+// query parameters are set with WithQuery. Calls other than GET are only
+// retried when they carry a key passed with WithIdempotencyKey or are marked
+// with WithRetryableCall.
+func Do[T any](ctx context.Context, c *SwervpayClient, method, path string, body interface{}, opts ...CallOption) (*T, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("swervpay: invalid path %q: %w", path, err)
+	}
+	if u.IsAbs() || u.Host != "" {
+		return nil, fmt.Errorf("swervpay: path %q must be relative to the base URL", path)
+	}
+
+	req, err := c.NewRequest(ctx, method, strings.TrimLeft(path, "/"), body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(T)
+
+	_, err = c.Perform(req, response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// EscapePath joins path segments with "/", escaping each one so that
+// identifiers containing "/", "?" or "#" stay within their segment.
+func EscapePath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// WithQuery sets query parameters of the call. query may be url.Values, a
+// map[string]string, or a struct or pointer to struct whose exported fields
+// are encoded under their `url` or `json` tag name. Fields tagged "-" are
+// skipped, as are zero values of fields tagged omitempty. Slices are encoded
+// as repeated parameters.
+func WithQuery(query interface{}) CallOption {
+	return func(s *callSettings) {
+		s.query = query
+	}
+}
+
+// encodeQuery converts a value accepted by WithQuery into url.Values.
+func encodeQuery(query interface{}) (url.Values, error) {
+	switch q := query.(type) {
+	case nil:
+		return nil, nil
+	case url.Values:
+		return q, nil
+	case map[string]string:
+		v := url.Values{}
+		for key, value := range q {
+			v.Set(key, value)
+		}
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(query)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("swervpay: unsupported query type %T", query)
+	}
+
+	v := url.Values{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("url")
+		if !ok {
+			tag = field.Tag.Get("json")
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		value := rv.Field(i)
+		if strings.Contains(flags, "omitempty") && value.IsZero() {
+			continue
+		}
+
+		if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+			for j := 0; j < value.Len(); j++ {
+				s, err := formatQueryValue(value.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("swervpay: query field %s: %w", field.Name, err)
+				}
+				v.Add(name, s)
+			}
+			continue
+		}
+
+		s, err := formatQueryValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("swervpay: query field %s: %w", field.Name, err)
+		}
+		v.Set(name, s)
+	}
+
+	return v, nil
+}
+
+// formatQueryValue formats a scalar value as a query parameter.
+func formatQueryValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return "", nil
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Ptr:
+		return formatQueryValue(v.Elem())
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
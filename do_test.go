@@ -0,0 +1,76 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type virtualAccount struct {
+	ID       string `json:"id"`
+	Currency string `json:"currency"`
+}
+
+func TestDo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual-accounts/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, r.URL.RawPath, "/virtual-accounts/va%2F001/statements")
+		assert.Equal(t, r.URL.Query(), url.Values{"page": {"2"}, "status": {"SUCCESSFUL", "PENDING"}})
+		_, _ = w.Write([]byte(`[{"id":"va/001","currency":"NGN"}]`))
+	})
+
+	query := struct {
+		Page   int      `url:"page"`
+		Limit  int      `url:"limit,omitempty"`
+		Status []string `json:"status"`
+		Secret string   `json:"-"`
+	}{Page: 2, Status: []string{"SUCCESSFUL", "PENDING"}, Secret: "ignored"}
+
+	resp, err := Do[[]virtualAccount](context.Background(), client, http.MethodGet, EscapePath("virtual-accounts", "va/001", "statements"), nil, WithQuery(&query))
+	assert.NoError(t, err)
+	assert.Equal(t, *resp, []virtualAccount{{ID: "va/001", Currency: "NGN"}})
+}
+
+func TestDoSendsBodyAndTypesErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/virtual-accounts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		assert.Regexp(t, uuidPattern, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"customer not found"}`))
+	})
+
+	_, err := Do[virtualAccount](context.Background(), client, http.MethodPost, "/virtual-accounts", map[string]string{"customer_id": "cus_001"}, WithIdempotencyKey("01890a5d-ac96-4b3a-8c3a-9d7a9d1a2b3c"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDoRejectsAbsoluteURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := Do[virtualAccount](context.Background(), client, http.MethodGet, "https://example.com/steal", nil)
+	assert.Error(t, err)
+	_, err = Do[virtualAccount](context.Background(), client, http.MethodGet, "//example.com/steal", nil)
+	assert.Error(t, err)
+}
+
+func TestEncodeQuery(t *testing.T) {
+	v, err := encodeQuery(map[string]string{"a": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, v.Encode(), "a=1")
+
+	v, err = encodeQuery(&PageAndLimitQuery{Page: 1, Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, v.Encode(), "limit=20&page=1")
+
+	_, err = encodeQuery([]string{"a"})
+	assert.Error(t, err)
+}
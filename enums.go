@@ -0,0 +1,147 @@
+package swervpay
+
+import "strings"
+
+// TransactionStatus is the status of a transaction. The API has been seen
+// to send it in either case, e.g. "success" or "PENDING"; comparisons ignore case.
+type TransactionStatus string
+
+const (
+	TransactionStatusPending    TransactionStatus = "pending"  // Not settled yet.
+	TransactionStatusSuccessful TransactionStatus = "success"  // Settled.
+	TransactionStatusFailed     TransactionStatus = "failed"   // Not settled; no money moved.
+	TransactionStatusReversed   TransactionStatus = "reversed" // Settled, then returned.
+)
+
+// IsKnown reports whether s is one of the documented statuses.
+func (s TransactionStatus) IsKnown() bool {
+	return oneOf(s, TransactionStatusPending, TransactionStatusSuccessful, TransactionStatusFailed, TransactionStatusReversed)
+}
+
+// IsTerminal reports whether the transaction will not change status anymore.
+// Unknown statuses are not terminal, so callers keep polling.
+func (s TransactionStatus) IsTerminal() bool {
+	return oneOf(s, TransactionStatusSuccessful, TransactionStatusFailed, TransactionStatusReversed)
+}
+
+// IsSuccessful reports whether the transaction settled.
+func (s TransactionStatus) IsSuccessful() bool {
+	return oneOf(s, TransactionStatusSuccessful)
+}
+
+// TransactionType is the kind of a transaction: its direction, or for card
+// and fx transactions the operation.
+type TransactionType string
+
+const (
+	TransactionTypeCredit   TransactionType = "credit"   // Money in.
+	TransactionTypeDebit    TransactionType = "debit"    // Money out.
+	TransactionTypeFund     TransactionType = "fund"     // Card funding.
+	TransactionTypeWithdraw TransactionType = "withdraw" // Withdrawal from a card.
+	TransactionTypeFx       TransactionType = "fx"       // Currency exchange.
+)
+
+// IsKnown reports whether t is one of the documented types.
+func (t TransactionType) IsKnown() bool {
+	return oneOf(t, TransactionTypeCredit, TransactionTypeDebit, TransactionTypeFund, TransactionTypeWithdraw, TransactionTypeFx)
+}
+
+// TransactionCategory is what a transaction was for.
+type TransactionCategory string
+
+const (
+	TransactionCategoryTransfer TransactionCategory = "transfer" // Transfer received into a wallet or collection.
+	TransactionCategoryPayout   TransactionCategory = "payout"   // Payout to a bank account.
+	TransactionCategoryCard     TransactionCategory = "card"     // Card funding or withdrawal.
+	TransactionCategoryExchange TransactionCategory = "exchange" // Currency exchange.
+	TransactionCategoryBill     TransactionCategory = "bill"     // Bill payment.
+)
+
+// IsKnown reports whether c is one of the documented categories.
+func (c TransactionCategory) IsKnown() bool {
+	return oneOf(c, TransactionCategoryTransfer, TransactionCategoryPayout, TransactionCategoryCard,
+		TransactionCategoryExchange, TransactionCategoryBill)
+}
+
+// CardStatus is the status of a card.
+type CardStatus string
+
+const (
+	CardStatusPending    CardStatus = "PENDING"    // Being issued.
+	CardStatusActive     CardStatus = "ACTIVE"     // Usable.
+	CardStatusFrozen     CardStatus = "FROZEN"     // Temporarily blocked; can be unfrozen.
+	CardStatusTerminated CardStatus = "TERMINATED" // Permanently closed.
+)
+
+// IsKnown reports whether s is one of the documented statuses.
+func (s CardStatus) IsKnown() bool {
+	return oneOf(s, CardStatusPending, CardStatusActive, CardStatusFrozen, CardStatusTerminated)
+}
+
+// CanFund reports whether a card with this status can be funded.
+func (s CardStatus) CanFund() bool {
+	return oneOf(s, CardStatusActive)
+}
+
+// CardType is the kind of card.
+type CardType string
+
+const (
+	CardTypeDefault  CardType = "DEFAULT"  // Card issued to a customer.
+	CardTypeBusiness CardType = "BUSINESS" // Card issued to a business; requires RC number and director BVN.
+)
+
+// IsKnown reports whether t is one of the documented types.
+func (t CardType) IsKnown() bool {
+	return oneOf(t, CardTypeDefault, CardTypeBusiness)
+}
+
+// CardIssuer is the network a card is issued on.
+type CardIssuer string
+
+const (
+	CardIssuerMastercard CardIssuer = "MASTERCARD" // Mastercard.
+	CardIssuerVisa       CardIssuer = "VISA"       // Visa.
+)
+
+// IsKnown reports whether i is one of the documented issuers.
+func (i CardIssuer) IsKnown() bool {
+	return oneOf(i, CardIssuerMastercard, CardIssuerVisa)
+}
+
+// CustomerStatus is the status of a customer.
+type CustomerStatus string
+
+const (
+	CustomerStatusActive      CustomerStatus = "active"      // Can transact.
+	CustomerStatusInactive    CustomerStatus = "inactive"    // Not verified yet or deactivated.
+	CustomerStatusBlacklisted CustomerStatus = "blacklisted" // Blocked from transacting.
+)
+
+// IsKnown reports whether s is one of the documented statuses.
+func (s CustomerStatus) IsKnown() bool {
+	return oneOf(s, CustomerStatusActive, CustomerStatusInactive, CustomerStatusBlacklisted)
+}
+
+// CollectionType is the kind of account a collection creates.
+type CollectionType string
+
+const (
+	CollectionTypeDefault CollectionType = "DEFAULT"  // Permanent account that accepts any number of payments.
+	CollectionTypeOneTime CollectionType = "ONE_TIME" // Account that accepts a single payment.
+)
+
+// IsKnown reports whether t is one of the documented types.
+func (t CollectionType) IsKnown() bool {
+	return oneOf(t, CollectionTypeDefault, CollectionTypeOneTime)
+}
+
+// oneOf reports whether v is one of values, ignoring case.
+func oneOf[T ~string](v T, values ...T) bool {
+	for _, value := range values {
+		if strings.EqualFold(string(v), string(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionStatus(t *testing.T) {
+	assert.True(t, TransactionStatusSuccessful.IsTerminal())
+	assert.True(t, TransactionStatusSuccessful.IsSuccessful())
+	assert.True(t, TransactionStatusFailed.IsTerminal())
+	assert.False(t, TransactionStatusFailed.IsSuccessful())
+	assert.True(t, TransactionStatusReversed.IsTerminal())
+	assert.False(t, TransactionStatusPending.IsTerminal())
+
+	assert.True(t, TransactionStatus("SUCCESS").IsSuccessful())
+	assert.True(t, TransactionStatus("PENDING").IsKnown())
+	assert.False(t, TransactionStatus("ON_HOLD").IsKnown())
+	assert.False(t, TransactionStatus("ON_HOLD").IsTerminal())
+}
+
+func TestEnumsIsKnown(t *testing.T) {
+	assert.True(t, TransactionTypeDebit.IsKnown())
+	assert.False(t, TransactionType("REFUND").IsKnown())
+	assert.True(t, TransactionCategoryExchange.IsKnown())
+	assert.False(t, TransactionCategory("LOAN").IsKnown())
+	assert.True(t, CardTypeBusiness.IsKnown())
+	assert.False(t, CardType("PHYSICAL").IsKnown())
+	assert.True(t, CardIssuerVisa.IsKnown())
+	assert.False(t, CardIssuer("AMEX").IsKnown())
+	assert.True(t, CustomerStatusBlacklisted.IsKnown())
+	assert.False(t, CustomerStatus("").IsKnown())
+	assert.True(t, CollectionTypeOneTime.IsKnown())
+	assert.False(t, CollectionType("RECURRING").IsKnown())
+}
+
+// TestEnumsMatchFixtures decodes the values the API fixtures of the other
+// tests send, so that the constants cannot drift from the wire values.
+func TestEnumsMatchFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		status   TransactionStatus
+		typ      TransactionType
+		category TransactionCategory
+		terminal bool
+	}{
+		{"transaction", `{"status": "success", "type": "credit", "category": "transfer"}`, TransactionStatusSuccessful, TransactionTypeCredit, TransactionCategoryTransfer, true},
+		{"payout", `{"status": "success", "type": "debit", "category": "payout"}`, TransactionStatusSuccessful, TransactionTypeDebit, TransactionCategoryPayout, true},
+		{"card fund", `{"status": "success", "type": "fund", "category": "card"}`, TransactionStatusSuccessful, TransactionTypeFund, TransactionCategoryCard, true},
+		{"card withdraw", `{"status": "success", "type": "withdraw", "category": "card"}`, TransactionStatusSuccessful, TransactionTypeWithdraw, TransactionCategoryCard, true},
+		{"fx", `{"status": "success", "type": "fx", "category": "exchange"}`, TransactionStatusSuccessful, TransactionTypeFx, TransactionCategoryExchange, true},
+		{"bill", `{"status": "PENDING", "type": "DEBIT", "category": "BILL"}`, TransactionStatusPending, TransactionTypeDebit, TransactionCategoryBill, false},
+	}
+
+	for _, tt := range tests {
+		var tx Transaction
+		assert.NoError(t, json.Unmarshal([]byte(tt.body), &tx), tt.name)
+
+		assert.True(t, tx.Status.IsKnown(), tt.name)
+		assert.True(t, oneOf(tx.Status, tt.status), tt.name)
+		assert.Equal(t, tt.terminal, tx.Status.IsTerminal(), tt.name)
+		assert.Equal(t, tt.terminal, tx.Status.IsSuccessful(), tt.name)
+		assert.True(t, tx.Type.IsKnown(), tt.name)
+		assert.True(t, oneOf(tx.Type, tt.typ), tt.name)
+		assert.True(t, tx.Category.IsKnown(), tt.name)
+		assert.True(t, oneOf(tx.Category, tt.category), tt.name)
+	}
+
+	var bill BillTransaction
+	assert.NoError(t, json.Unmarshal([]byte(`{"status": "success", "category": "electricity"}`), &bill))
+	assert.True(t, bill.Status.IsSuccessful())
+
+	var customer Customer
+	assert.NoError(t, json.Unmarshal([]byte(`{"status": "active"}`), &customer))
+	assert.Equal(t, CustomerStatusActive, customer.Status)
+}
+
+func TestCardStatusCanFund(t *testing.T) {
+	assert.True(t, CardStatusActive.CanFund())
+	assert.True(t, CardStatus("active").CanFund())
+	assert.False(t, CardStatusFrozen.CanFund())
+	assert.False(t, CardStatusTerminated.CanFund())
+	assert.False(t, CardStatus("SUSPENDED").CanFund())
+}
+
+func TestUnknownEnumsDecode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"id": "card_001", "status": "SUSPENDED", "type": "DEFAULT", "issuer": "VERVE"}`)
+	})
+
+	card, err := client.Card.Get(context.Background(), "card_001")
+	assert.NoError(t, err)
+	assert.Equal(t, CardStatus("SUSPENDED"), card.Status)
+	assert.False(t, card.Status.IsKnown())
+	assert.Equal(t, CardTypeDefault, card.Type)
+	assert.False(t, card.Issuer.IsKnown())
+
+	out, err := json.Marshal(card)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"status":"SUSPENDED"`)
+}
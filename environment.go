@@ -0,0 +1,213 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Environment is the Swervpay environment a client talks to.
+type Environment string
+
+const (
+	EnvironmentSandbox Environment = "sandbox" // Test environment; no real money moves.
+	EnvironmentLive    Environment = "live"    // Production environment.
+
+	// EnvironmentUnknown is the environment of a client whose base URL is not
+	// one of Swervpay's hosts, e.g. a proxy, when none was selected. The
+	// sandbox-only guards treat it like live.
+	EnvironmentUnknown Environment = ""
+)
+
+var (
+	// ErrSandboxOnly is returned by sandbox-only helpers, such as Wallet.Credit,
+	// when the client is configured for the live environment.
+	ErrSandboxOnly = errors.New("swervpay: operation is only available in the sandbox")
+
+	// ErrEnvironmentMismatch is matched through errors.Is by *EnvironmentMismatchError.
+	ErrEnvironmentMismatch = errors.New("swervpay: credentials do not match environment")
+
+	// ErrLiveOptInRequired is returned when RequireExplicitLive is set and the
+	// client would talk to the live environment without it being selected explicitly.
+	ErrLiveOptInRequired = errors.New("swervpay: live environment must be selected explicitly")
+)
+
+// EnvironmentMismatchError is returned on authentication when the API rejects
+// the credentials in the environment the client is configured for, but
+// accepts them in the other one.
+type EnvironmentMismatchError struct {
+	Environment Environment // Environment the client is configured for.
+	Credentials Environment // Environment the credentials belong to.
+	Err         error       // Error of the rejected authentication.
+}
+
+// Error implements the error interface.
+func (e *EnvironmentMismatchError) Error() string {
+	return fmt.Sprintf("swervpay: %s credentials used with the %s environment", e.Credentials, e.Environment)
+}
+
+// Is reports whether target is ErrEnvironmentMismatch.
+func (e *EnvironmentMismatchError) Is(target error) bool {
+	return target == ErrEnvironmentMismatch
+}
+
+// Unwrap returns the error of the rejected authentication.
+func (e *EnvironmentMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// WithEnvironment selects the environment explicitly. Unless a base URL is
+// set, it also selects the environment's API host.
+func WithEnvironment(env Environment) Option {
+	return func(o *clientOptions) error {
+		o.config.Environment = env
+		return nil
+	}
+}
+
+// WithRequireExplicitLive makes the client refuse to talk to the live
+// environment unless it was selected with WithEnvironment(EnvironmentLive).
+// It is meant for CLIs and scripts, which should default to the sandbox.
+func WithRequireExplicitLive(require bool) Option {
+	return func(o *clientOptions) error {
+		o.config.RequireExplicitLive = require
+		return nil
+	}
+}
+
+// Environment returns the environment the client talks to, EnvironmentUnknown
+// for a base URL of another host when no environment was selected.
+func (c *SwervpayClient) Environment() Environment {
+	return c.environment
+}
+
+// resolveEnvironment determines the environment of config. An explicit
+// Environment wins; otherwise Sandbox or a sandbox base URL select the
+// sandbox, no base URL or a live one selects live, and any other base URL
+// leaves the environment unknown.
+func resolveEnvironment(config *SwervpayClientOption) (Environment, error) {
+	switch config.Environment {
+	case "", EnvironmentSandbox, EnvironmentLive:
+	default:
+		return "", fmt.Errorf("%w: unknown environment %q, use %q or %q", ErrInvalidConfig, config.Environment, EnvironmentSandbox, EnvironmentLive)
+	}
+
+	if config.Environment == EnvironmentLive && config.Sandbox {
+		return "", fmt.Errorf("%w: environment %q conflicts with Sandbox", ErrInvalidConfig, config.Environment)
+	}
+
+	if host := baseURLEnvironment(config.BaseURL); host != "" && config.Environment != "" && host != config.Environment {
+		return "", fmt.Errorf("%w: environment %q conflicts with base URL %s", ErrInvalidConfig, config.Environment, config.BaseURL)
+	}
+
+	env := config.Environment
+	if env == "" {
+		switch {
+		case config.Sandbox:
+			env = EnvironmentSandbox
+		case config.BaseURL == "":
+			env = EnvironmentLive
+		default:
+			env = baseURLEnvironment(config.BaseURL)
+		}
+
+		// An unknown environment may be live as well.
+		if env != EnvironmentSandbox && config.RequireExplicitLive {
+			return "", fmt.Errorf("%w: %w", ErrInvalidConfig, ErrLiveOptInRequired)
+		}
+	}
+
+	return env, nil
+}
+
+// baseURLEnvironment returns the environment of one of Swervpay's own API
+// hosts, or "" for any other base URL.
+func baseURLEnvironment(baseURL string) Environment {
+	u, err := url.Parse(baseURL)
+	if err != nil || baseURL == "" {
+		return ""
+	}
+
+	switch u.Host {
+	case sandboxHost:
+		return EnvironmentSandbox
+	case liveHost:
+		return EnvironmentLive
+	}
+	return ""
+}
+
+// Hosts of Swervpay's environments; variables so tests can stand in for them.
+var (
+	sandboxHost = mustHost(sandboxBaseURL)
+	liveHost    = mustHost(liveBaseURL)
+)
+
+func mustHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u.Host
+}
+
+// checkCredentials is called when authentication was rejected with authErr.
+// When the client talks to one of Swervpay's hosts, it tries the credentials
+// on the other environment's host and reports a mismatch if they are
+// accepted there. Secret keys do not tell their environment, so the API has
+// to. The other host is tried at most once per client, and never after the
+// credentials were accepted; authErr is returned as is otherwise.
+func (c *SwervpayClient) checkCredentials(ctx context.Context, authErr error) error {
+	if !errors.Is(authErr, ErrUnauthorized) && !errors.Is(authErr, ErrForbidden) {
+		return authErr
+	}
+
+	err := authErr
+	c.credentialCheck.Do(func() { err = c.probeOtherEnvironment(ctx, authErr) })
+	return err
+}
+
+// probeOtherEnvironment tries the client's credentials on the host of the
+// other Swervpay environment.
+func (c *SwervpayClient) probeOtherEnvironment(ctx context.Context, authErr error) error {
+	var host string
+	var credentials Environment
+	switch baseURLEnvironment(c.BaseURL.String()) {
+	case EnvironmentSandbox:
+		host, credentials = liveHost, EnvironmentLive
+	case EnvironmentLive:
+		host, credentials = sandboxHost, EnvironmentSandbox
+	default:
+		return authErr
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, "auth", nil)
+	if err != nil {
+		return authErr
+	}
+	req.URL.Host, req.Host = host, host
+
+	resp, err := c.sendAuth(req)
+	if err != nil {
+		return authErr
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return authErr
+	}
+	return &EnvironmentMismatchError{Environment: c.environment, Credentials: credentials, Err: authErr}
+}
+
+// requireSandbox refuses sandbox-only operations on a client that is not
+// configured for the sandbox.
+func (c *SwervpayClient) requireSandbox(operation string) error {
+	if c.environment != EnvironmentSandbox {
+		return fmt.Errorf("%w: %s refused, the client is not configured for the sandbox", ErrSandboxOnly, operation)
+	}
+	return nil
+}
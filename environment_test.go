@@ -0,0 +1,184 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEnvironment(t *testing.T) {
+	cases := []struct {
+		config SwervpayClientOption
+		want   Environment
+	}{
+		{SwervpayClientOption{}, EnvironmentLive},
+		{SwervpayClientOption{Sandbox: true}, EnvironmentSandbox},
+		{SwervpayClientOption{BaseURL: sandboxBaseURL}, EnvironmentSandbox},
+		{SwervpayClientOption{BaseURL: liveBaseURL}, EnvironmentLive},
+		{SwervpayClientOption{BaseURL: "https://proxy.example.com/"}, EnvironmentUnknown},
+		{SwervpayClientOption{Environment: EnvironmentSandbox, BaseURL: "https://proxy.example.com/"}, EnvironmentSandbox},
+		{SwervpayClientOption{Environment: EnvironmentLive, RequireExplicitLive: true}, EnvironmentLive},
+		{SwervpayClientOption{Sandbox: true, RequireExplicitLive: true}, EnvironmentSandbox},
+	}
+
+	for _, tc := range cases {
+		env, err := resolveEnvironment(&tc.config)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, env, "%+v", tc.config)
+	}
+}
+
+func TestResolveEnvironmentErrors(t *testing.T) {
+	for _, config := range []SwervpayClientOption{
+		{Environment: "production"},
+		{Environment: EnvironmentLive, Sandbox: true},
+		{Environment: EnvironmentSandbox, BaseURL: liveBaseURL},
+		{RequireExplicitLive: true},
+		{BaseURL: "https://proxy.example.com/", RequireExplicitLive: true},
+	} {
+		_, err := resolveEnvironment(&config)
+		assert.ErrorIs(t, err, ErrInvalidConfig, "%+v", config)
+	}
+
+	_, err := New(WithCredentials("biz_001", "sk_001"), WithRequireExplicitLive(true))
+	assert.ErrorIs(t, err, ErrLiveOptInRequired)
+
+	c, err := New(WithCredentials("biz_001", "sk_001"), WithRequireExplicitLive(true), WithEnvironment(EnvironmentSandbox))
+	assert.NoError(t, err)
+	assert.Equal(t, c.Environment(), EnvironmentSandbox)
+	assert.Equal(t, c.BaseURL.String(), sandboxBaseURL)
+}
+
+func TestSandboxOnlyHelpersRefuseLive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	assert.Equal(t, client.Environment(), EnvironmentLive)
+
+	_, err := client.Wallet.Credit(context.Background(), "wallet_001", &CreditWalletBody{Amount: 100})
+	assert.ErrorIs(t, err, ErrSandboxOnly)
+
+	_, err = client.Collection.Credit(context.Background(), "collection_001", &CreditWalletBody{Amount: 100})
+	assert.ErrorIs(t, err, ErrSandboxOnly)
+
+	// A proxy may lead to live, so it is refused unless the sandbox is selected.
+	proxied := NewSwervpayClient(&SwervpayClientOption{BaseURL: server.URL})
+	assert.Equal(t, EnvironmentUnknown, proxied.Environment())
+	_, err = proxied.Wallet.Credit(context.Background(), "wallet_001", &CreditWalletBody{Amount: 100})
+	assert.ErrorIs(t, err, ErrSandboxOnly)
+}
+
+// standInHosts points sandboxHost and liveHost at test servers until the test ends.
+func standInHosts(t *testing.T, sandbox, live *httptest.Server) {
+	sandboxURL, _ := url.Parse(sandbox.URL)
+	liveURL, _ := url.Parse(live.URL)
+
+	oldSandbox, oldLive := sandboxHost, liveHost
+	sandboxHost, liveHost = sandboxURL.Host, liveURL.Host
+	t.Cleanup(func() { sandboxHost, liveHost = oldSandbox, oldLive })
+}
+
+// Secret keys have no documented prefix, so the key is opaque like a real one.
+const opaqueSecretKey = "9f3c2a7e5b1d4c8fa06e2b9d7c3f1a58"
+
+func TestEnvironmentMismatchOnAuth(t *testing.T) {
+	sandbox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Invalid credentials"}`))
+	}))
+	defer sandbox.Close()
+
+	var liveAuths int
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/auth", r.URL.Path)
+		businessID, secretKey, _ := r.BasicAuth()
+		assert.Equal(t, "biz_001", businessID)
+		assert.Equal(t, opaqueSecretKey, secretKey)
+		liveAuths++
+		_, _ = w.Write([]byte(`{"access_token":"token_live"}`))
+	}))
+	defer live.Close()
+
+	standInHosts(t, sandbox, live)
+
+	c, err := New(WithCredentials("biz_001", opaqueSecretKey), WithBaseURL(sandbox.URL+"/api/v1"))
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentSandbox, c.Environment())
+
+	_, err = c.Business.Get(context.Background())
+	assert.ErrorIs(t, err, ErrEnvironmentMismatch)
+	assert.ErrorIs(t, err, ErrAuthentication)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 1, liveAuths)
+
+	var mismatch *EnvironmentMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, mismatch.Environment, EnvironmentSandbox)
+		assert.Equal(t, mismatch.Credentials, EnvironmentLive)
+	}
+}
+
+func TestInvalidCredentialsAreNotAMismatch(t *testing.T) {
+	reject := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	sandbox := httptest.NewServer(reject)
+	defer sandbox.Close()
+	live := httptest.NewServer(reject)
+	defer live.Close()
+
+	standInHosts(t, sandbox, live)
+
+	c, err := New(WithCredentials("biz_001", opaqueSecretKey), WithBaseURL(live.URL+"/api/v1"))
+	assert.NoError(t, err)
+
+	_, err = c.Business.Get(context.Background())
+	assert.ErrorIs(t, err, ErrAuthentication)
+	assert.NotErrorIs(t, err, ErrEnvironmentMismatch)
+}
+
+func TestEnvironmentMismatchCheckedOnce(t *testing.T) {
+	reject := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	sandbox := httptest.NewServer(reject)
+	defer sandbox.Close()
+
+	var liveAuths int
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		liveAuths++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer live.Close()
+
+	standInHosts(t, sandbox, live)
+
+	// The probe goes through the client's middlewares like any auth request.
+	var seen []string
+	trace := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.URL.Host)
+			return next(req)
+		}
+	}
+
+	c, err := New(WithCredentials("biz_001", opaqueSecretKey), WithBaseURL(sandbox.URL+"/api/v1"), WithMiddleware(trace))
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = c.Business.Get(context.Background())
+		assert.ErrorIs(t, err, ErrAuthentication)
+		assert.NotErrorIs(t, err, ErrEnvironmentMismatch)
+	}
+
+	assert.Equal(t, 1, liveAuths)
+	assert.Equal(t, []string{sandboxHost, liveHost, sandboxHost}, seen)
+}
@@ -0,0 +1,202 @@
+package swervpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError through errors.Is.
+var (
+	ErrNotFound          = errors.New("swervpay: not found")
+	ErrUnauthorized      = errors.New("swervpay: unauthorized")
+	ErrForbidden         = errors.New("swervpay: forbidden")
+	ErrRateLimited       = errors.New("swervpay: rate limited")
+	ErrValidation        = errors.New("swervpay: validation failed")
+	ErrInsufficientFunds = errors.New("swervpay: insufficient funds")
+)
+
+// requestIDHeaders lists the response headers checked, in order, for a request id.
+// X-Correlation-Id is left out: the client sends it, and an echo of it is not
+// the API's own id.
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id"}
+
+// ErrorDetail represents a field-level error returned by the Swervpay API.
+type ErrorDetail struct {
+	Field   string `json:"field"`   // Field that failed, when the API reports one.
+	Message string `json:"message"` // Description of the failure.
+}
+
+// APIError represents a non-successful response from the Swervpay API.
+type APIError struct {
+	StatusCode int           // HTTP status code of the response.
+	Name       string        // Error name reported by the API, e.g. "BadRequestException".
+	Message    string        // Human readable error message.
+	Details    []ErrorDetail // Field-level details, if any.
+	RequestID  string        // Request id taken from the response headers, if any.
+	Body       []byte        // Raw response body.
+	Header     http.Header   // Response headers.
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if msg == "" {
+		msg = "Unknown Error"
+	}
+	return "[ERROR]: " + msg
+}
+
+// Is reports whether the error belongs to the class of the given sentinel error.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrInsufficientFunds:
+		return e.StatusCode == http.StatusPaymentRequired ||
+			containsFold(e.Name, "insufficient") || containsFold(e.Message, "insufficient")
+	}
+	return false
+}
+
+// errorBody is the union of the error payload shapes returned by the Swervpay API.
+type errorBody struct {
+	StatusCode int             `json:"statusCode"`
+	Name       string          `json:"name"`
+	Error      string          `json:"error"`
+	Message    json.RawMessage `json:"message"`
+	Errors     json.RawMessage `json:"errors"`
+}
+
+func handleError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Header:     resp.Header,
+		RequestID:  responseRequestID(resp),
+	}
+
+	r := &errorBody{}
+	if len(bytes.TrimSpace(body)) > 0 && json.Unmarshal(body, r) == nil {
+		apiErr.Name = r.Name
+		if apiErr.Name == "" {
+			apiErr.Name = r.Error
+		}
+		apiErr.Message, apiErr.Details = decodeErrorMessage(r.Message)
+		apiErr.Details = append(apiErr.Details, decodeErrorDetails(r.Errors)...)
+	}
+
+	if apiErr.Message == "" && resp.StatusCode == http.StatusNotFound {
+		apiErr.Message = "Not Found"
+	}
+
+	return apiErr
+}
+
+// responseRequestID returns the request id the API attached to resp, if any.
+func responseRequestID(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	for _, h := range requestIDHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// decodeErrorMessage accepts either a plain message or a list of validation
+// messages, which some endpoints return in place of a single string.
+func decodeErrorMessage(raw json.RawMessage) (string, []ErrorDetail) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var msg string
+	if json.Unmarshal(raw, &msg) == nil {
+		return msg, nil
+	}
+
+	var msgs []string
+	if json.Unmarshal(raw, &msgs) == nil {
+		details := make([]ErrorDetail, 0, len(msgs))
+		for _, m := range msgs {
+			details = append(details, ErrorDetail{Message: m})
+		}
+		return strings.Join(msgs, "; "), details
+	}
+
+	return "", nil
+}
+
+// decodeErrorDetails accepts the list and map shapes of the `errors` attribute.
+func decodeErrorDetails(raw json.RawMessage) []ErrorDetail {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []ErrorDetail
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+
+	var single map[string]string
+	if json.Unmarshal(raw, &single) == nil {
+		details := make([]ErrorDetail, 0, len(single))
+		for field, msg := range single {
+			details = append(details, ErrorDetail{Field: field, Message: msg})
+		}
+		sortErrorDetails(details)
+		return details
+	}
+
+	var multi map[string][]string
+	if json.Unmarshal(raw, &multi) == nil {
+		var details []ErrorDetail
+		for field, msgs := range multi {
+			for _, msg := range msgs {
+				details = append(details, ErrorDetail{Field: field, Message: msg})
+			}
+		}
+		sortErrorDetails(details)
+		return details
+	}
+
+	return nil
+}
+
+func sortErrorDetails(details []ErrorDetail) {
+	sort.SliceStable(details, func(i, j int) bool { return details[i].Field < details[j].Field })
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// InvalidRequestError represents an error caused by the client.
+type InvalidRequestError struct {
+	StatusCode int    `json:"statusCode,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Message    string `json:"message"`
+}
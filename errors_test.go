@@ -0,0 +1,99 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_123")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"statusCode":400,"name":"BadRequestException","message":"Invalid bank code","errors":{"bank_code":"must be 3 digits"}}`))
+	})
+
+	_, err := client.Payout.Create(context.Background(), &CreatePayoutBody{}, WithCallValidation(false))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, apiErr.StatusCode, http.StatusBadRequest)
+		assert.Equal(t, apiErr.Name, "BadRequestException")
+		assert.Equal(t, apiErr.Message, "Invalid bank code")
+		assert.Equal(t, apiErr.RequestID, "req_123")
+		assert.Equal(t, apiErr.Details, []ErrorDetail{{Field: "bank_code", Message: "must be 3 digits"}})
+		assert.Contains(t, string(apiErr.Body), "BadRequestException")
+	}
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "[ERROR]: Invalid bank code")
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/cards/card_404", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.Card.Get(context.Background(), "card_404")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "[ERROR]: Not Found")
+}
+
+func TestResponseRequestIDIgnoresCorrelationEcho(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("X-Correlation-Id", "corr_001")
+	assert.Empty(t, responseRequestID(resp))
+
+	resp.Header.Set("X-Request-Id", "req_123")
+	assert.Equal(t, "req_123", responseRequestID(resp))
+}
+
+func TestAPIErrorClasses(t *testing.T) {
+	cases := []struct {
+		err    *APIError
+		target error
+	}{
+		{&APIError{StatusCode: http.StatusTooManyRequests}, ErrRateLimited},
+		{&APIError{StatusCode: http.StatusUnauthorized}, ErrUnauthorized},
+		{&APIError{StatusCode: http.StatusForbidden}, ErrForbidden},
+		{&APIError{StatusCode: http.StatusUnprocessableEntity}, ErrValidation},
+		{&APIError{StatusCode: http.StatusBadRequest, Message: "Insufficient balance"}, ErrInsufficientFunds},
+		{&APIError{StatusCode: http.StatusBadRequest, Name: "InsufficientFundsException"}, ErrInsufficientFunds},
+	}
+
+	for _, c := range cases {
+		assert.ErrorIs(t, c.err, c.target)
+	}
+
+	assert.NotErrorIs(t, &APIError{StatusCode: http.StatusBadRequest, Message: "Invalid amount"}, ErrInsufficientFunds)
+	assert.NotErrorIs(t, &APIError{StatusCode: http.StatusForbidden}, ErrUnauthorized)
+	assert.NotErrorIs(t, &APIError{StatusCode: http.StatusUnauthorized}, ErrForbidden)
+}
+
+func TestAPIErrorMessageList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/customers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":["email must be an email","country should not be empty"]}`))
+	})
+
+	_, err := client.Customer.Create(context.Background(), &CreateCustomerBody{}, WithCallValidation(false))
+
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, apiErr.Message, "email must be an email; country should not be empty")
+		assert.Len(t, apiErr.Details, 2)
+	}
+}
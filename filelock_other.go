@@ -0,0 +1,42 @@
+//go:build !unix
+
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	// lockPollInterval is how often a contended file lock is retried.
+	lockPollInterval = 10 * time.Millisecond
+	// staleLockAge is the age after which a lock file left behind by a crashed process is removed.
+	staleLockAge = 30 * time.Second
+)
+
+// lockFile creates path exclusively, waiting until it is available or ctx is
+// done. Lock files older than staleLockAge are assumed abandoned and removed.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(path)
+			continue
+		}
+
+		if err := sleepContext(ctx, lockPollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
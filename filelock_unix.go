@@ -0,0 +1,43 @@
+//go:build unix
+
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// lockPollInterval is how often a contended file lock is retried.
+const lockPollInterval = 10 * time.Millisecond
+
+// lockFile takes an exclusive flock on path, waiting until it is available or
+// ctx is done. The kernel releases the lock if the process dies.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return func() {
+				_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+				f.Close()
+			}, nil
+		}
+
+		if !errors.Is(err, syscall.EWOULDBLOCK) && !errors.Is(err, syscall.EINTR) {
+			f.Close()
+			return nil, err
+		}
+
+		if err := sleepContext(ctx, lockPollInterval); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+}
@@ -6,10 +6,23 @@ import (
 )
 
 // FxBody represents the body of a foreign exchange request.
+// Amount can be set from an exact decimal with Amount.Float64.
 type FxBody struct {
-	Amount float64 `json:"amount"` // Amount is the amount to be converted.
-	From   string  `json:"from"`   // From is the currency to convert from.
-	To     string  `json:"to"`     // To is the currency to convert to.
+	Amount float64  `json:"amount"` // Amount is the amount to be converted.
+	From   Currency `json:"from"`   // From is the currency to convert from.
+	To     Currency `json:"to"`     // To is the currency to convert to.
+}
+
+// Validate checks the amount and currencies before the request is sent.
+func (b FxBody) Validate() error {
+	v := newValidation("FxBody")
+	v.positive("amount", b.Amount)
+	v.currency("from", b.From, true)
+	v.currency("to", b.To, true)
+	if b.From != "" && b.From == b.To {
+		v.fail("to", "must differ from from")
+	}
+	return v.result()
 }
 
 // FxRateResponse represents the response from a foreign exchange rate request.
@@ -21,16 +34,21 @@ type FxRateResponse struct {
 
 // FromOrTo represents a currency and amount in a foreign exchange operation.
 type FromOrTo struct {
-	Amount   float64 `json:"amount"`   // Amount is the amount in the currency.
-	Currency string  `json:"currency"` // Currency is the currency code.
+	Amount   float64  `json:"amount"`   // Amount is the amount in the currency.
+	Currency Currency `json:"currency"` // Currency is the currency code.
+}
+
+// Money returns the amount in its currency.
+func (f FromOrTo) Money() Money {
+	return NewMoney(AmountFromFloat(f.Amount), f.Currency)
 }
 
 // FxInt is an interface for foreign exchange operations.
 type FxInt interface {
 	// Rate gets the conversion rate for a foreign exchange operation.
-	Rate(ctx context.Context, body FxBody) (*FxRateResponse, error)
+	Rate(ctx context.Context, body FxBody, opts ...CallOption) (*FxRateResponse, error)
 	// Exchange performs a foreign exchange operation.
-	Exchange(ctx context.Context, body FxBody) (*Transaction, error)
+	Exchange(ctx context.Context, body FxBody, opts ...CallOption) (*Transaction, error)
 }
 
 // FxIntImpl is an implementation of the FxInt interface.
@@ -43,8 +61,8 @@ var _ FxInt = &FxIntImpl{}
 
 // Rate gets the conversion rate for a foreign exchange operation.
 // https://docs.swervpay.co/api-reference/fx/get
-func (f FxIntImpl) Rate(ctx context.Context, body FxBody) (*FxRateResponse, error) {
-	req, err := f.client.NewRequest(ctx, http.MethodPost, "fx/rate", body)
+func (f FxIntImpl) Rate(ctx context.Context, body FxBody, opts ...CallOption) (*FxRateResponse, error) {
+	req, err := f.client.NewRequest(ctx, http.MethodPost, "fx/rate", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +79,12 @@ func (f FxIntImpl) Rate(ctx context.Context, body FxBody) (*FxRateResponse, erro
 }
 
 // Exchange performs a foreign exchange operation.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// marked with WithRetryableCall.
 // https://docs.swervpay.co/api-reference/fx/create
-func (f FxIntImpl) Exchange(ctx context.Context, body FxBody) (*Transaction, error) {
-	req, err := f.client.NewRequest(ctx, http.MethodPost, "fx/exchange", body)
+func (f FxIntImpl) Exchange(ctx context.Context, body FxBody, opts ...CallOption) (*Transaction, error) {
+	req, err := f.client.NewRequest(ctx, http.MethodPost, "fx/exchange", body, idempotent(opts, "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,5 +97,7 @@ func (f FxIntImpl) Exchange(ctx context.Context, body FxBody) (*Transaction, err
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
@@ -45,9 +45,9 @@ func TestFxRate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.Rate, 1500.0)
-	assert.Equal(t, resp.From.Currency, "NGN")
+	assert.Equal(t, resp.From.Currency, NGN)
 	assert.Equal(t, resp.From.Amount, 1000.0)
-	assert.Equal(t, resp.To.Currency, "USD")
+	assert.Equal(t, resp.To.Currency, USD)
 	assert.Equal(t, resp.To.Amount, 0.67)
 }
 
@@ -91,7 +91,7 @@ func TestFxExchange(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.ID, "fx_txn_001")
 	assert.Equal(t, resp.Amount, 0.67)
-	assert.Equal(t, resp.Status, "success")
-	assert.Equal(t, resp.Type, "fx")
+	assert.Equal(t, resp.Status, TransactionStatus("success"))
+	assert.Equal(t, resp.Type, TransactionType("fx"))
 	assert.Equal(t, resp.FiatRate, 1500.0)
 }
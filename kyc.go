@@ -0,0 +1,114 @@
+package swervpay
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// KycTier is the KYC tier a submission is for.
+type KycTier string
+
+const (
+	KycTier1 KycTier = "1" // Personal information verified against the BVN.
+	KycTier2 KycTier = "2" // Identity document.
+)
+
+// KycDocumentType is the type of an identity document submitted for tier 2.
+type KycDocumentType string
+
+const (
+	KycDocumentNIN            KycDocumentType = "NIN"             // National identification number slip; the number is 11 digits.
+	KycDocumentPassport       KycDocumentType = "PASSPORT"        // International passport.
+	KycDocumentDriversLicense KycDocumentType = "DRIVERS_LICENSE" // Driver's license.
+	KycDocumentVotersCard     KycDocumentType = "VOTERS_CARD"     // Permanent voter's card.
+)
+
+// IsKnown reports whether t is one of the documented document types.
+func (t KycDocumentType) IsKnown() bool {
+	return oneOf(t, KycDocumentNIN, KycDocumentPassport, KycDocumentDriversLicense, KycDocumentVotersCard)
+}
+
+//go:embed data/countries.txt
+var countryData string
+
+// countries holds the ISO 3166-1 alpha-2 country codes.
+var countries = func() map[string]bool {
+	m := map[string]bool{}
+	for _, code := range strings.Fields(countryData) {
+		m[code] = true
+	}
+	return m
+}()
+
+// NewTier1KycBody returns the body submitting info for tier 1, or a
+// *ValidationError naming every missing or malformed field.
+func NewTier1KycBody(info Tier1KycInput) (*CustomerKycBody, error) {
+	body := &CustomerKycBody{Tier: KycTier1, Tier1: info}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// NewTier2KycBody returns the body submitting doc for tier 2, or a
+// *ValidationError naming every missing or malformed field.
+func NewTier2KycBody(doc Tier2KycInput) (*CustomerKycBody, error) {
+	body := &CustomerKycBody{Tier: KycTier2, Tier2: doc}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// ValidateBVN checks that bvn is a Bank Verification Number of 11 digits.
+func ValidateBVN(bvn string) error {
+	return formatError("BVN", bvn, elevenDigits(bvn))
+}
+
+// ValidateNIN checks that nin is a National Identification Number of 11 digits.
+func ValidateNIN(nin string) error {
+	return formatError("NIN", nin, elevenDigits(nin))
+}
+
+// ValidatePhoneNumber checks that phone is in E.164 format, e.g. "+2348012345678".
+func ValidatePhoneNumber(phone string) error {
+	return formatError("phone number", phone, e164(phone))
+}
+
+// ValidateCountryCode checks that code is an ISO 3166-1 alpha-2 country code, e.g. "NG".
+func ValidateCountryCode(code string) error {
+	return formatError("country code", code, countryCode(code))
+}
+
+// formatError reports problem without the value, which may be sensitive.
+func formatError(what, value, problem string) error {
+	if problem == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s", ErrValidation, what, problem)
+}
+
+// elevenDigits returns what is wrong with a BVN or NIN, or "" when nothing is.
+func elevenDigits(s string) string {
+	if len(s) != 11 || !isDigits(s) {
+		return "must be 11 digits"
+	}
+	return ""
+}
+
+// e164 returns what is wrong with a phone number, or "" when nothing is.
+func e164(s string) string {
+	if !strings.HasPrefix(s, "+") || len(s) < 8 || len(s) > 16 || !isDigits(s[1:]) || s[1] == '0' {
+		return `must be in E.164 format, e.g. "+2348012345678"`
+	}
+	return ""
+}
+
+// countryCode returns what is wrong with a country code, or "" when nothing is.
+func countryCode(s string) string {
+	if !countries[s] {
+		return `must be an ISO 3166-1 alpha-2 country code, e.g. "NG"`
+	}
+	return ""
+}
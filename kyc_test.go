@@ -0,0 +1,124 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTier1KycBody(t *testing.T) {
+	info := Tier1KycInput{
+		Bvn:         "22212345678",
+		Address:     "1 Marina",
+		City:        "Lagos",
+		State:       "Lagos",
+		Country:     "NG",
+		PhoneNumber: "+2348012345678",
+	}
+
+	body, err := NewTier1KycBody(info)
+	assert.NoError(t, err)
+	assert.Equal(t, &CustomerKycBody{Tier: KycTier1, Tier1: info}, body)
+
+	info.Bvn = "2221234567"
+	info.PhoneNumber = "08012345678"
+	info.Country = "NGA"
+	body, err = NewTier1KycBody(info)
+	assert.Nil(t, body)
+
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, []ErrorDetail{
+			{Field: "information.bvn", Message: "must be 11 digits"},
+			{Field: "information.phone_number", Message: `must be in E.164 format, e.g. "+2348012345678"`},
+			{Field: "information.country", Message: `must be an ISO 3166-1 alpha-2 country code, e.g. "NG"`},
+		}, verr.Fields)
+	}
+	assert.NotContains(t, err.Error(), "2221234567")
+}
+
+func TestNewTier2KycBody(t *testing.T) {
+	doc := Tier2KycInput{DocumentType: KycDocumentNIN, DocumentNumber: "12345678901", Document: "https://example.com/nin.png"}
+
+	body, err := NewTier2KycBody(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, &CustomerKycBody{Tier: KycTier2, Tier2: doc}, body)
+
+	tests := []struct {
+		name   string
+		doc    Tier2KycInput
+		fields []string
+	}{
+		{"short nin", Tier2KycInput{DocumentType: KycDocumentNIN, DocumentNumber: "1234", Document: "nin.png"}, []string{"document.document_number"}},
+		{"passport number", Tier2KycInput{DocumentType: KycDocumentPassport, DocumentNumber: "A01234567", Document: "passport.png"}, nil},
+		{"unknown type", Tier2KycInput{DocumentType: "BVN", DocumentNumber: "22212345678", Document: "bvn.png"}, []string{"document.document_type"}},
+		{"empty", Tier2KycInput{}, []string{"document.document_type", "document.document_number", "document.document"}},
+	}
+
+	for _, tt := range tests {
+		_, err := NewTier2KycBody(tt.doc)
+		if tt.fields == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+
+		var verr *ValidationError
+		if assert.ErrorAs(t, err, &verr, tt.name) {
+			var fields []string
+			for _, f := range verr.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.fields, fields, tt.name)
+		}
+	}
+}
+
+func TestKycFieldValidators(t *testing.T) {
+	assert.NoError(t, ValidateBVN("22212345678"))
+	assert.ErrorIs(t, ValidateBVN("2221234567"), ErrValidation)
+	assert.ErrorIs(t, ValidateBVN("2221234567a"), ErrValidation)
+	assert.EqualError(t, ValidateBVN(""), "swervpay: validation failed: BVN must be 11 digits")
+
+	assert.NoError(t, ValidateNIN("12345678901"))
+	assert.ErrorIs(t, ValidateNIN("123456789012"), ErrValidation)
+
+	assert.NoError(t, ValidatePhoneNumber("+2348012345678"))
+	assert.NoError(t, ValidatePhoneNumber("+14155552671"))
+	for _, phone := range []string{"08012345678", "+08012345678", "+234 801 234 5678", "+1234", "+12345678901234567"} {
+		assert.ErrorIs(t, ValidatePhoneNumber(phone), ErrValidation, phone)
+	}
+
+	assert.NoError(t, ValidateCountryCode("NG"))
+	assert.NoError(t, ValidateCountryCode("GB"))
+	for _, code := range []string{"ng", "NGA", "XX", ""} {
+		assert.ErrorIs(t, ValidateCountryCode(code), ErrValidation, code)
+	}
+
+	assert.True(t, KycDocumentDriversLicense.IsKnown())
+	assert.True(t, KycDocumentType("passport").IsKnown())
+	assert.False(t, KycDocumentType("BVN").IsKnown())
+}
+
+func TestCustomerKycInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/customers/cust_001/kyc", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, err := client.Customer.Kyc(context.Background(), "cust_001", &CustomerKycBody{
+		Tier:  KycTier2,
+		Tier2: Tier2KycInput{DocumentType: KycDocumentNIN, DocumentNumber: "1234", Document: "nin.png"},
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		msg, ok := verr.Field("document.document_number")
+		assert.True(t, ok)
+		assert.Equal(t, "must be 11 digits", msg)
+	}
+}
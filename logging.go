@@ -0,0 +1,121 @@
+package swervpay
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// correlationIDHeader carries the id shared by every attempt of a call.
+const correlationIDHeader = "X-Correlation-Id"
+
+// WithLogger sets the logger requests are reported to. Every attempt is logged
+// with its method, path, status, latency, attempt number, correlation id and
+// business id.
+// At debug level, headers and bodies are logged too, with credentials, card
+// data and identity numbers redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// ensureCorrelationID sets a correlation id on req unless one is already set, and returns it.
+func ensureCorrelationID(req *http.Request) string {
+	if id := req.Header.Get(correlationIDHeader); id != "" {
+		return id
+	}
+
+	id, err := NewIdempotencyKey()
+	if err != nil {
+		return ""
+	}
+	req.Header.Set(correlationIDHeader, id)
+	return id
+}
+
+// logAttempt reports a finished attempt. Failed and retried attempts are
+// logged as warnings. At debug level the response body is buffered so it can
+// be logged and still be decoded afterwards.
+func (c *SwervpayClient) logAttempt(a attemptResult) {
+	if c.logger == nil {
+		return
+	}
+
+	ctx := a.req.Context()
+
+	level := slog.LevelInfo
+	if a.err != nil || a.retrying || (a.resp != nil && a.resp.StatusCode >= http.StatusBadRequest) {
+		level = slog.LevelWarn
+	}
+
+	debug := c.logger.Enabled(ctx, slog.LevelDebug)
+	if !c.logger.Enabled(ctx, level) && !debug {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", a.req.Method),
+		slog.String("path", a.req.URL.Path),
+		slog.Int("attempt", a.attempt),
+		slog.Duration("latency", a.latency),
+		slog.String("correlation_id", a.req.Header.Get(correlationIDHeader)),
+	}
+
+	if c.Config.BusinessID != "" {
+		attrs = append(attrs, slog.String("business_id", c.Config.BusinessID))
+	}
+	if a.resp != nil {
+		attrs = append(attrs, slog.Int("status", a.resp.StatusCode))
+	}
+	if a.requestID != "" {
+		attrs = append(attrs, slog.String("request_id", a.requestID))
+	}
+	if a.err != nil {
+		attrs = append(attrs, slog.String("error", a.err.Error()))
+	}
+	if a.retrying {
+		attrs = append(attrs, slog.Bool("retrying", true))
+	}
+
+	c.logger.LogAttrs(ctx, level, "swervpay request", attrs...)
+
+	if !debug {
+		return
+	}
+
+	debugAttrs := []slog.Attr{
+		slog.String("method", a.req.Method),
+		slog.String("path", a.req.URL.Path),
+		slog.Int("attempt", a.attempt),
+		slog.String("correlation_id", a.req.Header.Get(correlationIDHeader)),
+		slog.Any("request_headers", RedactHeader(a.req.Header)),
+	}
+	if c.Config.BusinessID != "" {
+		debugAttrs = append(debugAttrs, slog.String("business_id", c.Config.BusinessID))
+	}
+	if len(a.reqBody) > 0 {
+		debugAttrs = append(debugAttrs, slog.String("request_body", string(RedactBody(a.reqBody))))
+	}
+	if a.resp != nil {
+		debugAttrs = append(debugAttrs, slog.Any("response_headers", RedactHeader(a.resp.Header)))
+		if body := bufferBody(a.resp); len(body) > 0 {
+			debugAttrs = append(debugAttrs, slog.String("response_body", string(RedactBody(body))))
+		}
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "swervpay request detail", debugAttrs...)
+}
+
+// bufferBody reads the response body and replaces it with an in-memory copy.
+func bufferBody(resp *http.Response) []byte {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	return body
+}
@@ -0,0 +1,124 @@
+package swervpay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestLoggingAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+
+	calls := 0
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get(correlationIDHeader))
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req_001")
+		_, _ = w.Write([]byte(`{"id":"card_001","card_number":"5399831234567890","cvv":"123"}`))
+	})
+
+	resp, err := client.Card.Get(context.Background(), "card_001")
+	assert.NoError(t, err)
+	assert.Equal(t, resp.CardNumber, "5399831234567890")
+
+	lines := decodeLogLines(t, &buf)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, lines[0]["level"], "WARN")
+		assert.Equal(t, lines[0]["status"], float64(http.StatusServiceUnavailable))
+		assert.Equal(t, lines[0]["attempt"], float64(1))
+		assert.Equal(t, lines[0]["retrying"], true)
+
+		assert.Equal(t, lines[1]["level"], "INFO")
+		assert.Equal(t, lines[1]["msg"], "swervpay request")
+		assert.Equal(t, lines[1]["method"], http.MethodGet)
+		assert.Equal(t, lines[1]["path"], "/cards/card_001")
+		assert.Equal(t, lines[1]["status"], float64(http.StatusOK))
+		assert.Equal(t, lines[1]["attempt"], float64(2))
+		assert.Equal(t, lines[1]["request_id"], "req_001")
+		assert.Contains(t, lines[1], "latency")
+		assert.Equal(t, lines[1]["correlation_id"], lines[0]["correlation_id"])
+	}
+	assert.NotContains(t, buf.String(), "5399831234567890")
+}
+
+func TestLoggingDebugRedactsBodies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client.AccessToken = "secret-token"
+
+	mux.HandleFunc("/customers/cust_001/kyc", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"KYC information updated successfully"}`))
+	})
+
+	resp, err := client.Customer.Kyc(context.Background(), "cust_001", &CustomerKycBody{
+		Tier: "1",
+		Tier1: Tier1KycInput{
+			Bvn:         "22212345678",
+			Address:     "1 Marina",
+			City:        "Lagos",
+			State:       "Lagos",
+			Country:     "NG",
+			PhoneNumber: "+2348012345678",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Message, "KYC information updated successfully")
+
+	out := buf.String()
+	assert.Contains(t, out, "swervpay request detail")
+	assert.Contains(t, out, "KYC information updated successfully")
+	assert.Contains(t, out, "Lagos")
+	assert.NotContains(t, out, "22212345678")
+	assert.NotContains(t, out, "secret-token")
+}
+
+func TestLoggingDebugRedactsIdentifiersInText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mux.HandleFunc("/customers/cust_001", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"cust_001","report_message":"BVN 22212345678 could not be verified"}`))
+	})
+
+	_, err := client.Customer.Get(context.Background(), "cust_001")
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "could not be verified")
+	assert.NotContains(t, out, "22212345678")
+}
@@ -0,0 +1,270 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Error classes reported in RequestMetric.ErrorClass.
+const (
+	ErrorClassNone         = ""
+	ErrorClassTimeout      = "timeout"
+	ErrorClassCanceled     = "canceled"
+	ErrorClassNetwork      = "network"
+	ErrorClassUnauthorized = "unauthorized"
+	ErrorClassNotFound     = "not_found"
+	ErrorClassRateLimited  = "rate_limited"
+	ErrorClassValidation   = "validation"
+	ErrorClassClient       = "client_error"
+	ErrorClassServer       = "server_error"
+)
+
+// RequestMetric describes a single request attempt.
+type RequestMetric struct {
+	BusinessID string        // Business the client is authenticated as.
+	Route      string        // Templated route, e.g. "cards/{id}/fund".
+	Method     string        // HTTP method.
+	StatusCode int           // Response status code, zero when no response was received.
+	Duration   time.Duration // Time spent on the attempt.
+	Retry      int           // Number of attempts made before this one.
+	ErrorClass string        // Class of the failure, ErrorClassNone on success.
+}
+
+// MetricsCollector receives a RequestMetric after every request attempt.
+// Implementations must be safe for concurrent use and should not block.
+type MetricsCollector interface {
+	ObserveRequest(m RequestMetric)
+}
+
+// WithMetrics sets the collector request attempts are reported to.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(o *clientOptions) error {
+		o.metrics = collector
+		return nil
+	}
+}
+
+// errorClass classifies the outcome of an attempt.
+func errorClass(resp *http.Response, err error) string {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			return ErrorClassCanceled
+		case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+			return ErrorClassTimeout
+		default:
+			return ErrorClassNetwork
+		}
+	}
+
+	if resp == nil {
+		return ErrorClassNone
+	}
+
+	switch code := resp.StatusCode; {
+	case code < http.StatusBadRequest:
+		return ErrorClassNone
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrorClassUnauthorized
+	case code == http.StatusNotFound:
+		return ErrorClassNotFound
+	case code == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+		return ErrorClassValidation
+	case code < http.StatusInternalServerError:
+		return ErrorClassClient
+	default:
+		return ErrorClassServer
+	}
+}
+
+// DefaultLatencyBuckets are the histogram bucket upper bounds used by InMemoryMetrics.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// InMemoryMetrics is a dependency-free MetricsCollector that aggregates
+// request counts and latency histograms per business, route and method.
+type InMemoryMetrics struct {
+	mu        sync.Mutex
+	buckets   []time.Duration
+	endpoints map[endpointKey]*endpointStats
+}
+
+// Verify that InMemoryMetrics implements MetricsCollector.
+var _ MetricsCollector = &InMemoryMetrics{}
+
+type endpointKey struct {
+	businessID string
+	route      string
+	method     string
+}
+
+type endpointStats struct {
+	count    uint64
+	retries  uint64
+	sum      time.Duration
+	buckets  []uint64 // Non-cumulative counts; the last entry counts values above every bound.
+	statuses map[int]uint64
+	errors   map[string]uint64
+}
+
+// EndpointSnapshot is a point-in-time copy of the statistics of one business, route and method.
+type EndpointSnapshot struct {
+	BusinessID   string            // Business the requests were made for.
+	Route        string            // Templated route.
+	Method       string            // HTTP method.
+	Count        uint64            // Number of attempts.
+	Retries      uint64            // Number of attempts that were retries.
+	Sum          time.Duration     // Total latency of all attempts.
+	Buckets      []HistogramBucket // Cumulative latency histogram, in increasing bound order.
+	StatusCounts map[int]uint64    // Attempts per status code; zero counts attempts without a response.
+	ErrorCounts  map[string]uint64 // Attempts per error class, excluding successes.
+}
+
+// HistogramBucket is a cumulative histogram bucket.
+type HistogramBucket struct {
+	UpperBound time.Duration // Inclusive upper bound of the bucket.
+	Count      uint64        // Number of observations less than or equal to UpperBound.
+}
+
+// NewInMemoryMetrics creates an InMemoryMetrics with the given latency bucket
+// bounds, or DefaultLatencyBuckets when none are given.
+func NewInMemoryMetrics(buckets ...time.Duration) *InMemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	sorted := append([]time.Duration(nil), buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &InMemoryMetrics{buckets: sorted, endpoints: map[endpointKey]*endpointStats{}}
+}
+
+// ObserveRequest records a request attempt.
+func (m *InMemoryMetrics) ObserveRequest(r RequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.endpoints == nil {
+		m.endpoints = map[endpointKey]*endpointStats{}
+	}
+	if m.buckets == nil {
+		m.buckets = DefaultLatencyBuckets
+	}
+
+	key := endpointKey{businessID: r.BusinessID, route: r.Route, method: r.Method}
+	s, ok := m.endpoints[key]
+	if !ok {
+		s = &endpointStats{
+			buckets:  make([]uint64, len(m.buckets)+1),
+			statuses: map[int]uint64{},
+			errors:   map[string]uint64{},
+		}
+		m.endpoints[key] = s
+	}
+
+	s.count++
+	if r.Retry > 0 {
+		s.retries++
+	}
+	s.sum += r.Duration
+	s.buckets[sort.Search(len(m.buckets), func(i int) bool { return r.Duration <= m.buckets[i] })]++
+	s.statuses[r.StatusCode]++
+	if r.ErrorClass != ErrorClassNone {
+		s.errors[r.ErrorClass]++
+	}
+}
+
+// Snapshot returns the statistics collected so far, ordered by business, route and method.
+func (m *InMemoryMetrics) Snapshot() []EndpointSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]EndpointSnapshot, 0, len(m.endpoints))
+	for key, s := range m.endpoints {
+		snap := EndpointSnapshot{
+			BusinessID:   key.businessID,
+			Route:        key.route,
+			Method:       key.method,
+			Count:        s.count,
+			Retries:      s.retries,
+			Sum:          s.sum,
+			Buckets:      make([]HistogramBucket, len(m.buckets)),
+			StatusCounts: make(map[int]uint64, len(s.statuses)),
+			ErrorCounts:  make(map[string]uint64, len(s.errors)),
+		}
+
+		var cumulative uint64
+		for i, bound := range m.buckets {
+			cumulative += s.buckets[i]
+			snap.Buckets[i] = HistogramBucket{UpperBound: bound, Count: cumulative}
+		}
+		for code, n := range s.statuses {
+			snap.StatusCounts[code] = n
+		}
+		for class, n := range s.errors {
+			snap.ErrorCounts[class] = n
+		}
+
+		out = append(out, snap)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].BusinessID != out[j].BusinessID {
+			return out[i].BusinessID < out[j].BusinessID
+		}
+		if out[i].Route != out[j].Route {
+			return out[i].Route < out[j].Route
+		}
+		return out[i].Method < out[j].Method
+	})
+
+	return out
+}
+
+// Reset discards all collected statistics.
+func (m *InMemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoints = map[endpointKey]*endpointStats{}
+}
+
+// observeAttempt reports a finished attempt to the metrics collector, if any.
+func (c *SwervpayClient) observeAttempt(a attemptResult) {
+	if c.metrics == nil {
+		return
+	}
+
+	m := RequestMetric{
+		BusinessID: c.Config.BusinessID,
+		Route:      c.route(a.req),
+		Method:     a.req.Method,
+		Duration:   a.latency,
+		Retry:      a.attempt - 1,
+		ErrorClass: errorClass(a.resp, a.err),
+	}
+	if a.resp != nil {
+		m.StatusCode = a.resp.StatusCode
+	}
+
+	c.metrics.ObserveRequest(m)
+}
@@ -0,0 +1,114 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingCollector struct {
+	metrics []RequestMetric
+}
+
+func (r *recordingCollector) ObserveRequest(m RequestMetric) {
+	r.metrics = append(r.metrics, m)
+}
+
+func TestMetricsObserveAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	collector := &recordingCollector{}
+	client.metrics = collector
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+
+	calls := 0
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"Card funded successfully"}`))
+	})
+
+	_, err := client.Card.Fund(context.Background(), "card_001", &FundOrWithdrawCardBody{Amount: 100}, WithRetryableCall())
+	assert.NoError(t, err)
+
+	if assert.Len(t, collector.metrics, 2) {
+		first, second := collector.metrics[0], collector.metrics[1]
+
+		assert.Equal(t, first.Route, "cards/{id}/fund")
+		assert.Equal(t, first.Method, http.MethodPost)
+		assert.Equal(t, first.StatusCode, http.StatusBadGateway)
+		assert.Equal(t, first.Retry, 0)
+		assert.Equal(t, first.ErrorClass, ErrorClassServer)
+
+		assert.Equal(t, second.StatusCode, http.StatusOK)
+		assert.Equal(t, second.Retry, 1)
+		assert.Equal(t, second.ErrorClass, ErrorClassNone)
+		assert.Greater(t, second.Duration, time.Duration(0))
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	cases := []struct {
+		status int
+		err    error
+		class  string
+	}{
+		{http.StatusOK, nil, ErrorClassNone},
+		{http.StatusUnauthorized, nil, ErrorClassUnauthorized},
+		{http.StatusNotFound, nil, ErrorClassNotFound},
+		{http.StatusTooManyRequests, nil, ErrorClassRateLimited},
+		{http.StatusUnprocessableEntity, nil, ErrorClassValidation},
+		{http.StatusConflict, nil, ErrorClassClient},
+		{http.StatusServiceUnavailable, nil, ErrorClassServer},
+		{0, context.DeadlineExceeded, ErrorClassTimeout},
+		{0, context.Canceled, ErrorClassCanceled},
+		{0, errors.New("connection reset by peer"), ErrorClassNetwork},
+	}
+
+	for _, c := range cases {
+		var resp *http.Response
+		if c.status != 0 {
+			resp = &http.Response{StatusCode: c.status}
+		}
+		assert.Equal(t, errorClass(resp, c.err), c.class)
+	}
+}
+
+func TestInMemoryMetrics(t *testing.T) {
+	m := NewInMemoryMetrics(100*time.Millisecond, 10*time.Millisecond, time.Second)
+
+	m.ObserveRequest(RequestMetric{Route: "payouts", Method: http.MethodPost, StatusCode: 200, Duration: 5 * time.Millisecond})
+	m.ObserveRequest(RequestMetric{Route: "payouts", Method: http.MethodPost, StatusCode: 503, Duration: 50 * time.Millisecond, ErrorClass: ErrorClassServer})
+	m.ObserveRequest(RequestMetric{Route: "payouts", Method: http.MethodPost, StatusCode: 200, Duration: 2 * time.Second, Retry: 1})
+	m.ObserveRequest(RequestMetric{Route: "cards/{id}", Method: http.MethodGet, StatusCode: 200, Duration: time.Millisecond})
+
+	snap := m.Snapshot()
+	if assert.Len(t, snap, 2) {
+		assert.Equal(t, snap[0].Route, "cards/{id}")
+		assert.Equal(t, snap[0].Count, uint64(1))
+
+		payouts := snap[1]
+		assert.Equal(t, payouts.Route, "payouts")
+		assert.Equal(t, payouts.Count, uint64(3))
+		assert.Equal(t, payouts.Retries, uint64(1))
+		assert.Equal(t, payouts.Sum, 2055*time.Millisecond)
+		assert.Equal(t, payouts.Buckets, []HistogramBucket{
+			{UpperBound: 10 * time.Millisecond, Count: 1},
+			{UpperBound: 100 * time.Millisecond, Count: 2},
+			{UpperBound: time.Second, Count: 2},
+		})
+		assert.Equal(t, payouts.StatusCounts, map[int]uint64{200: 2, 503: 1})
+		assert.Equal(t, payouts.ErrorCounts, map[string]uint64{ErrorClassServer: 1})
+	}
+
+	m.Reset()
+	assert.Empty(t, m.Snapshot())
+}
@@ -0,0 +1,65 @@
+package swervpay
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Handler sends a request to the Swervpay API and returns its response.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the transport step of every request attempt. It sees the
+// outgoing request, fully authorized, and the resulting response or error. A
+// middleware may short-circuit the call by returning without calling next.
+type Middleware func(next Handler) Handler
+
+// errNilResponse is returned when a handler returns neither a response nor an error.
+var errNilResponse = errors.New("swervpay: middleware returned no response and no error")
+
+// Use registers middlewares that wrap every request sent by the client. They
+// run in the order given, before any per-call middleware. Use is not safe to
+// call while requests are in flight.
+func (c *SwervpayClient) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+}
+
+// WithMiddleware registers middlewares that wrap every request sent by the client.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *clientOptions) error {
+		o.middlewares = append(o.middlewares, mw...)
+		return nil
+	}
+}
+
+// WithCallMiddleware adds middlewares that wrap the requests of a single call.
+// They run after the client's middlewares.
+func WithCallMiddleware(mw ...Middleware) CallOption {
+	return func(s *callSettings) {
+		s.middlewares = append(s.middlewares, mw...)
+	}
+}
+
+// handler builds the chain of client and per-call middlewares around the HTTP client.
+func (c *SwervpayClient) handler(settings *callSettings) Handler {
+	var h Handler = func(req *http.Request) (*http.Response, error) {
+		return c.client.Do(req)
+	}
+
+	for i := len(settings.middlewares) - 1; i >= 0; i-- {
+		h = settings.middlewares[i](h)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := h(req)
+		if err == nil && resp == nil {
+			return nil, errNilResponse
+		}
+		if resp != nil && resp.Body == nil {
+			resp.Body = http.NoBody
+		}
+		return resp, err
+	}
+}
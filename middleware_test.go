@@ -0,0 +1,116 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, log *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			*log = append(*log, name+" before")
+			resp, err := next(req)
+			*log = append(*log, name+" after")
+			return resp, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("X-Correlation-Id"), "corr_001")
+		_, _ = w.Write([]byte(`{"message":"Card funded successfully"}`))
+	})
+
+	var log []string
+	client.Use(recordingMiddleware("client", &log), func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Correlation-Id", "corr_001")
+			return next(req)
+		}
+	})
+
+	_, err := client.Card.Fund(context.Background(), "card_001", &FundOrWithdrawCardBody{Amount: 10},
+		WithCallMiddleware(recordingMiddleware("call", &log)))
+	assert.NoError(t, err)
+	assert.Equal(t, log, []string{"client before", "call before", "call after", "client after"})
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"id":"txn_cached"}`)),
+				Request:    req,
+			}, nil
+		}
+	})
+
+	resp, err := client.Transaction.Get(context.Background(), "txn_001")
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, "txn_cached")
+}
+
+func TestMiddlewareSeesResponseAndError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 1}
+
+	mux.HandleFunc("/wallets/wal_001", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var status int
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return resp, err
+		}
+	})
+
+	_, err := client.Wallet.Get(context.Background(), "wal_001")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, status, http.StatusNotFound)
+
+	blocked := errors.New("blocked by policy")
+	_, err = client.Payout.Create(context.Background(), &CreatePayoutBody{}, WithCallValidation(false), WithCallMiddleware(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, blocked
+		}
+	}))
+	assert.ErrorIs(t, err, blocked)
+}
+
+func TestMiddlewareNilResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 1}
+
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		}
+	})
+
+	_, err := client.Business.Get(context.Background())
+	assert.ErrorIs(t, err, errNilResponse)
+}
@@ -0,0 +1,401 @@
+package swervpay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxAmountScale is the largest number of decimal places an Amount keeps.
+	maxAmountScale = 18
+
+	// maxFloatScale is the number of decimal places AmountFromFloat rounds to.
+	maxFloatScale = 8
+
+	// maxAmountDigits bounds the digits of a parsed amount, so that a large
+	// exponent such as "1e999999" cannot exhaust memory.
+	maxAmountDigits = 400
+)
+
+var (
+	// ErrInvalidAmount is returned when a string is not a valid decimal amount.
+	ErrInvalidAmount = errors.New("swervpay: invalid amount")
+
+	// ErrCurrencyMismatch is returned when Money of different currencies is combined.
+	ErrCurrencyMismatch = errors.New("swervpay: currency mismatch")
+)
+
+// Amount is an exact decimal number, such as 1250.50. Unlike float64, sums
+// and differences of Amounts never drift, and arithmetic never overflows.
+// The zero value is 0.
+//
+// Amounts marshal to JSON numbers with exactly their decimal digits, and
+// unmarshal from JSON numbers or strings without going through float64.
+type Amount struct {
+	coef  string // Value scaled by 10^scale, in decimal; "" for zero.
+	scale uint8  // Number of decimal places.
+}
+
+// NewAmount returns value scaled by 10^-scale, e.g. NewAmount(125050, 2) is
+// 1250.50. It panics if scale is not between 0 and 18.
+func NewAmount(value int64, scale int) Amount {
+	if scale < 0 || scale > maxAmountScale {
+		panic(fmt.Sprintf("swervpay: amount scale %d out of range", scale))
+	}
+	return newAmount(big.NewInt(value), uint8(scale))
+}
+
+// ParseAmount parses a decimal number such as "1250.50", "-3" or "1.5e3".
+// The decimal places written are kept, so "1.50" formats as "1.50".
+func ParseAmount(s string) (Amount, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "e")
+	exp := 0
+	if hasExponent {
+		n, err := strconv.Atoi(exponent)
+		if err != nil {
+			return Amount{}, invalid
+		}
+		if n > maxAmountDigits || n < -maxAmountDigits {
+			return Amount{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+		}
+		exp = n
+	}
+
+	neg := false
+	switch {
+	case strings.HasPrefix(mantissa, "-"):
+		neg, mantissa = true, mantissa[1:]
+	case strings.HasPrefix(mantissa, "+"):
+		mantissa = mantissa[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Amount{}, invalid
+	}
+
+	scale := len(fracPart) - exp
+	if len(digits) > maxAmountDigits || len(digits)-scale > maxAmountDigits {
+		return Amount{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+	if scale < 0 {
+		digits += strings.Repeat("0", -scale)
+		scale = 0
+	}
+	for scale > maxAmountScale && strings.HasSuffix(digits, "0") {
+		digits, scale = digits[:len(digits)-1], scale-1
+	}
+	if scale > maxAmountScale {
+		return Amount{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, maxAmountScale)
+	}
+
+	v, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		v.Neg(v)
+	}
+	return newAmount(v, uint8(scale)), nil
+}
+
+// MustParseAmount is like ParseAmount but panics on invalid input. It is
+// meant for constants in code and tests.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// AmountFromFloat converts f to an Amount rounded to 8 decimal places, with
+// trailing zeros dropped, so AmountFromFloat(0.1) is exactly 0.1 and
+// AmountFromFloat(0.1+0.2) is 0.3. Non-finite values yield zero.
+func AmountFromFloat(f float64) Amount {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Amount{}
+	}
+
+	s := strings.TrimRight(strconv.FormatFloat(f, 'f', maxFloatScale, 64), "0")
+	a, err := ParseAmount(strings.TrimSuffix(s, "."))
+	if err != nil {
+		return Amount{}
+	}
+	return a
+}
+
+// Float64 returns the nearest float64, for fields that are still float64.
+func (a Amount) Float64() float64 {
+	f, _ := strconv.ParseFloat(a.String(), 64)
+	return f
+}
+
+// Scale returns the number of decimal places of a.
+func (a Amount) Scale() int {
+	return int(a.scale)
+}
+
+// String formats a with its decimal places, e.g. "1250.50".
+func (a Amount) String() string {
+	s := a.coef
+	if s == "" {
+		s = "0"
+	}
+	if a.scale == 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if pad := int(a.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(a.scale)] + "." + s[len(s)-int(a.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) Amount {
+	x, y, scale := align(a, b)
+	return newAmount(x.Add(x, y), scale)
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) Amount {
+	return a.Add(b.Neg())
+}
+
+// Mul returns a multiplied by n, e.g. a unit price times a quantity.
+func (a Amount) Mul(n int64) Amount {
+	v := a.value()
+	return newAmount(v.Mul(v, big.NewInt(n)), a.scale)
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	v := a.value()
+	return newAmount(v.Neg(v), a.scale)
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	v := a.value()
+	return newAmount(v.Abs(v), a.scale)
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of a.
+func (a Amount) Sign() int {
+	switch {
+	case a.coef == "":
+		return 0
+	case a.coef[0] == '-':
+		return -1
+	default:
+		return 1
+	}
+}
+
+// IsZero reports whether a is zero.
+func (a Amount) IsZero() bool {
+	return a.coef == ""
+}
+
+// Cmp compares a and b and returns -1, 0 or 1. Amounts that differ only in
+// trailing zeros, such as 1.5 and 1.50, are equal.
+func (a Amount) Cmp(b Amount) int {
+	x, y, _ := align(a, b)
+	return x.Cmp(y)
+}
+
+// Equal reports whether a and b are the same number.
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Round rounds a to scale decimal places, rounding halves away from zero.
+// scale is limited to between 0 and 18.
+func (a Amount) Round(scale int) Amount {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale > maxAmountScale {
+		scale = maxAmountScale
+	}
+	if scale >= int(a.scale) {
+		return newAmount(a.rescale(uint8(scale)), uint8(scale))
+	}
+
+	div := pow10Big(int(a.scale) - scale)
+	q, r := new(big.Int).QuoRem(a.value(), div, new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if r.Cmp(div) >= 0 {
+		q.Add(q, big.NewInt(int64(a.Sign())))
+	}
+	return newAmount(q, uint8(scale))
+}
+
+// MinorUnits returns a as an integer number of minor units of a currency
+// with the given number of decimal places, e.g. kobo for NGN with 2. It fails
+// if a has more decimal places than the currency, or does not fit in an int64.
+func (a Amount) MinorUnits(decimals int) (int64, error) {
+	if decimals < 0 || decimals > maxAmountScale {
+		return 0, fmt.Errorf("%w: %d decimal places out of range", ErrInvalidAmount, decimals)
+	}
+	rounded := a.Round(decimals)
+	if rounded.Cmp(a) != 0 {
+		return 0, fmt.Errorf("%w: %s has more than %d decimal places", ErrInvalidAmount, a, decimals)
+	}
+	v := rounded.value()
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("%w: %s is out of range", ErrInvalidAmount, a)
+	}
+	return v.Int64(), nil
+}
+
+// MarshalJSON encodes a as a JSON number with exactly its decimal digits.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or a string holding one.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+
+	v, err := ParseAmount(string(data))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// MarshalText encodes a as its decimal string.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes a decimal string.
+func (a *Amount) UnmarshalText(text []byte) error {
+	v, err := ParseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// newAmount returns the Amount of v scaled by 10^-scale. The coefficient is
+// kept as a string so that Amounts stay comparable, e.g. as map keys.
+func newAmount(v *big.Int, scale uint8) Amount {
+	if v.Sign() == 0 {
+		return Amount{scale: scale}
+	}
+	return Amount{coef: v.String(), scale: scale}
+}
+
+// value returns a new big.Int holding the coefficient of a.
+func (a Amount) value() *big.Int {
+	v := new(big.Int)
+	if a.coef != "" {
+		v.SetString(a.coef, 10)
+	}
+	return v
+}
+
+// rescale returns the coefficient of a at a larger or equal scale.
+func (a Amount) rescale(scale uint8) *big.Int {
+	v := a.value()
+	return v.Mul(v, pow10Big(int(scale-a.scale)))
+}
+
+// align returns the coefficients of a and b at their common scale.
+func align(a, b Amount) (*big.Int, *big.Int, uint8) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+func pow10Big(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Money is an Amount in a currency.
+type Money struct {
+	Amount   Amount   `json:"amount"`   // Amount in major units, e.g. 1250.50.
+	Currency Currency `json:"currency"` // ISO 4217 currency code, e.g. "NGN".
+}
+
+// NewMoney returns amount in currency.
+func NewMoney(amount Amount, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns m + o. It fails if the currencies differ.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - o. It fails if the currencies differ.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Sub(o.Amount), Currency: m.Currency}, nil
+}
+
+// Cmp compares m and o and returns -1, 0 or 1. It fails if the currencies differ.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String formats m as the currency code followed by the amount, e.g. "NGN 1250.50".
+func (m Money) String() string {
+	return strings.TrimSpace(string(m.Currency) + " " + m.Amount.String())
+}
+
+// Format formats m for display, e.g. "₦1,250.00". See Currency.Format.
+func (m Money) Format() string {
+	return m.Currency.Format(m.Amount)
+}
+
+// MinorUnits returns m as an integer number of the currency's minor units,
+// e.g. 125050 kobo for NGN 1250.50. It fails if m has more decimal places
+// than the currency.
+func (m Money) MinorUnits() (int64, error) {
+	return m.Amount.MinorUnits(m.Currency.MinorUnits())
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if !strings.EqualFold(string(m.Currency), string(o.Currency)) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1250.50", "1250.50"},
+		{"1250.10", "1250.10"},
+		{"-3", "-3"},
+		{"+7.5", "7.5"},
+		{"0.001", "0.001"},
+		{"-0.05", "-0.05"},
+		{"1.5e3", "1500"},
+		{"125E-2", "1.25"},
+		{" 42 ", "42"},
+		{".5", "0.5"},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, a.String(), tt.in)
+	}
+
+	for _, in := range []string{"", "-", "abc", "1.2.3", "1e", "1,000", "0.0000000000000000001", "1e401", "1e-9999999999"} {
+		_, err := ParseAmount(in)
+		assert.ErrorIs(t, err, ErrInvalidAmount, in)
+	}
+
+	assert.Panics(t, func() { MustParseAmount("x") })
+
+	long, err := ParseAmount("12345678901234567890.12")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.12", long.String())
+}
+
+func TestAmountFromFloat(t *testing.T) {
+	assert.Equal(t, "0.1", AmountFromFloat(0.1).String())
+	assert.Equal(t, "1250.1", AmountFromFloat(1250.10).String())
+	assert.Equal(t, "-42", AmountFromFloat(-42).String())
+	assert.True(t, AmountFromFloat(0.1).Add(AmountFromFloat(0.2)).Equal(MustParseAmount("0.3")))
+	assert.True(t, AmountFromFloat(0).IsZero())
+	assert.Equal(t, 1250.5, MustParseAmount("1250.50").Float64())
+
+	// Float sums are rounded to 8 decimal places instead of keeping binary noise.
+	x, y := 0.1, 0.2
+	assert.Equal(t, "0.3", AmountFromFloat(x+y).String())
+	assert.Equal(t, "1000.3", MustParseAmount("1000").Add(AmountFromFloat(x+y)).String())
+	assert.Equal(t, "0.12345679", AmountFromFloat(0.123456789).String())
+	assert.Equal(t, 1e300, AmountFromFloat(1e300).Float64())
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := MustParseAmount("1250.50")
+	b := MustParseAmount("0.255")
+
+	assert.Equal(t, "1250.755", a.Add(b).String())
+	assert.Equal(t, "1250.245", a.Sub(b).String())
+	assert.Equal(t, "3751.50", a.Mul(3).String())
+	assert.Equal(t, "-1250.50", a.Neg().String())
+	assert.Equal(t, "1250.50", a.Neg().Abs().String())
+	assert.Equal(t, -1, a.Neg().Sign())
+	assert.Equal(t, 0, NewAmount(0, 2).Sign())
+
+	assert.Equal(t, 0, MustParseAmount("1.5").Cmp(MustParseAmount("1.50")))
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+
+	assert.Equal(t, "0.26", b.Round(2).String())
+	assert.Equal(t, "-0.26", b.Neg().Round(2).String())
+	assert.Equal(t, "0.25", MustParseAmount("0.254").Round(2).String())
+	assert.Equal(t, "1250.5000", a.Round(4).String())
+
+	kobo, err := a.MinorUnits(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(125050), kobo)
+
+	_, err = b.MinorUnits(2)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	// Results beyond int64 are exact rather than a panic.
+	huge := NewAmount(math.MaxInt64, 0)
+	assert.Equal(t, "18446744073709551614", huge.Add(huge).String())
+	assert.Equal(t, "36893488147419103228", huge.Mul(4).String())
+	assert.Equal(t, "9223372036854775808", NewAmount(math.MinInt64, 0).Neg().String())
+	assert.Equal(t, "0.000000000000000001", MustParseAmount("1e-18").Add(NewAmount(0, 0)).String())
+	assert.Equal(t, "92233720368547758070.000000000000000000", NewAmount(math.MaxInt64, 0).Mul(10).Round(18).String())
+
+	_, err = huge.Mul(100).MinorUnits(2)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	assert.Equal(t, "1", MustParseAmount("1.499").Round(-1).String())
+}
+
+func TestAmountJSON(t *testing.T) {
+	var body struct {
+		Amount  Amount  `json:"amount"`
+		Charges Amount  `json:"charges"`
+		Fee     *Amount `json:"fee"`
+	}
+
+	err := json.Unmarshal([]byte(`{"amount": 1250.10, "charges": "10.75", "fee": null}`), &body)
+	assert.NoError(t, err)
+	assert.Equal(t, "1250.10", body.Amount.String())
+	assert.Equal(t, "10.75", body.Charges.String())
+	assert.Nil(t, body.Fee)
+
+	data, err := json.Marshal(body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1250.10, "charges": 10.75, "fee": null}`, string(data))
+	assert.Contains(t, string(data), `"amount":1250.10`)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": true}`), &body))
+
+	var m map[Amount]int
+	assert.NoError(t, json.Unmarshal([]byte(`{"1.50": 1}`), &m))
+	assert.Equal(t, 1, m[MustParseAmount("1.50")])
+}
+
+func TestMoney(t *testing.T) {
+	a := NewMoney(MustParseAmount("1250.50"), "NGN")
+	b := NewMoney(MustParseAmount("49.50"), "ngn")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "NGN 1300.00", sum.String())
+
+	diff, err := a.Sub(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "NGN 1201.00", diff.String())
+
+	cmp, err := a.Cmp(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = a.Add(NewMoney(MustParseAmount("1"), "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = a.Cmp(NewMoney(MustParseAmount("1"), "USD"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	data, err := json.Marshal(a)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1250.50, "currency": "NGN"}`, string(data))
+	assert.True(t, Money{}.IsZero())
+}
+
+func TestTransactionAmountDecimal(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, `[`)
+		for i := 0; i < 10; i++ {
+			if i > 0 {
+				fmt.Fprint(w, `,`)
+			}
+			fmt.Fprintf(w, `{"id": "txn_%d", "amount": 0.1, "charges": 0.2}`, i)
+		}
+		fmt.Fprint(w, `]`)
+	})
+
+	transactions, err := client.Transaction.Gets(context.Background(), &PageAndLimitQuery{Page: 1, Limit: 10})
+	assert.NoError(t, err)
+
+	total := NewAmount(0, 2)
+	for _, tx := range transactions {
+		total = total.Add(tx.AmountDecimal()).Add(tx.ChargesDecimal())
+	}
+	assert.Equal(t, "3.00", total.String())
+}
+
+func TestModelMoneyAccessors(t *testing.T) {
+	card := Card{Balance: 1250.1, TotalFunded: 5000, Currency: "USD"}
+	assert.Equal(t, "1250.1", card.BalanceDecimal().String())
+	assert.Equal(t, "USD 1250.1", card.BalanceMoney().String())
+	assert.Equal(t, "5000", card.TotalFundedDecimal().String())
+
+	item := BillerItem{Amount: 1000, Fee: 100.5, Currency: "NGN"}
+	assert.Equal(t, "NGN 1000", item.AmountMoney().String())
+	assert.Equal(t, "NGN 100.5", item.FeeMoney().String())
+
+	assert.Equal(t, "NGN 2.5", FromOrTo{Amount: 2.5, Currency: "NGN"}.Money().String())
+}
@@ -0,0 +1,118 @@
+package swervpay
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrInvalidAccountNumber is returned for an account number that is not a
+	// valid NUBAN of its bank, usually because of a mistyped digit.
+	ErrInvalidAccountNumber = errors.New("swervpay: invalid account number")
+
+	// ErrUnknownBankCode is returned for a bank code that is not in the CBN
+	// bank-code table embedded in the SDK.
+	ErrUnknownBankCode = errors.New("swervpay: unknown bank code")
+)
+
+//go:embed data/banks.json
+var bankData []byte
+
+// banks is the table of CBN bank codes, by code.
+var banks = loadBanks(bankData)
+
+func loadBanks(data []byte) map[string]Bank {
+	var list []Bank
+	if err := json.Unmarshal(data, &list); err != nil {
+		panic(fmt.Sprintf("swervpay: invalid bank table: %v", err))
+	}
+
+	m := make(map[string]Bank, len(list))
+	for _, b := range list {
+		m[b.Code] = b
+	}
+	return m
+}
+
+// LookupBank returns the bank with the given CBN code from the table embedded
+// in the SDK. Use Other.Banks for the live list.
+func LookupBank(code string) (Bank, bool) {
+	b, ok := banks[code]
+	return b, ok
+}
+
+// KnownBanks returns the banks of the embedded CBN bank-code table, sorted by code.
+func KnownBanks() []Bank {
+	list := make([]Bank, 0, len(banks))
+	for _, b := range banks {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// ValidateNUBAN checks that accountNumber is a valid 10-digit NUBAN of the
+// bank with the given 3- or 6-digit CBN code. The error wraps
+// ErrInvalidAccountNumber or ErrUnknownBankCode.
+func ValidateNUBAN(accountNumber, bankCode string) error {
+	if field, message, err := nubanIssue(accountNumber, bankCode); err != nil {
+		return fmt.Errorf("%w: %s %s", err, field, message)
+	}
+	return nil
+}
+
+// nubanIssue returns the field at fault, a message and the sentinel error
+// when accountNumber is not a valid NUBAN of bankCode.
+func nubanIssue(accountNumber, bankCode string) (string, string, error) {
+	if !isDigits(bankCode) || (len(bankCode) != 3 && len(bankCode) != 6) {
+		return "bank_code", "must be a 3 or 6 digit CBN bank code", ErrUnknownBankCode
+	}
+	bank, ok := banks[bankCode]
+	if !ok {
+		return "bank_code", fmt.Sprintf("%q is not a known CBN bank code", bankCode), ErrUnknownBankCode
+	}
+
+	if !isDigits(accountNumber) || len(accountNumber) != 10 {
+		return "account_number", "must be 10 digits", ErrInvalidAccountNumber
+	}
+	if validNUBAN(accountNumber, bankCode) {
+		return "", "", nil
+	}
+
+	// The check digit catches any single mistyped digit and most swaps of
+	// adjacent digits, but cannot tell which digit is wrong.
+	return "account_number", fmt.Sprintf("fails the NUBAN check for %s (%s); a digit is likely mistyped or swapped", bank.Name, bankCode), ErrInvalidAccountNumber
+}
+
+// validNUBAN reports whether the last digit of accountNumber is the check
+// digit of its first nine digits and bankCode. Codes of deposit money banks
+// are padded to the six digits of other institutions, as the CBN standard
+// does, which leaves their legacy check digits unchanged.
+func validNUBAN(accountNumber, bankCode string) bool {
+	if len(bankCode) == 3 {
+		bankCode = "000" + bankCode
+	}
+	digits := bankCode + accountNumber[:9]
+
+	weights := [3]int{3, 7, 3}
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * weights[i%3]
+	}
+	return int(accountNumber[9]-'0') == (10-sum%10)%10
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNUBAN(t *testing.T) {
+	assert.NoError(t, ValidateNUBAN("0123456785", "058"))
+	assert.NoError(t, ValidateNUBAN("1234567896", "058"))
+	assert.NoError(t, ValidateNUBAN("0000000000", "011"))
+
+	err := ValidateNUBAN("0123456789", "058")
+	assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+	assert.Contains(t, err.Error(), "Guaranty Trust Bank (058)")
+
+	assert.ErrorIs(t, ValidateNUBAN("012345678", "058"), ErrInvalidAccountNumber)
+	assert.ErrorIs(t, ValidateNUBAN("01234567a5", "058"), ErrInvalidAccountNumber)
+	assert.ErrorIs(t, ValidateNUBAN("0123456785", "999"), ErrUnknownBankCode)
+	assert.ErrorIs(t, ValidateNUBAN("0123456785", "58"), ErrUnknownBankCode)
+}
+
+func TestNUBANSixDigitCodes(t *testing.T) {
+	// A 3-digit code and the same code padded to 6 digits agree.
+	assert.Equal(t, validNUBAN("0123456785", "058"), validNUBAN("0123456785", "000058"))
+
+	var valid string
+	for d := byte('0'); d <= '9'; d++ {
+		if account := "123456789" + string(d); validNUBAN(account, "090267") {
+			valid = account
+		}
+	}
+	assert.NoError(t, ValidateNUBAN(valid, "090267"))
+}
+
+func TestNUBANTypos(t *testing.T) {
+	_, message, err := nubanIssue("0123456785", "058")
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+
+	// Every single-digit typo of a valid number is caught.
+	valid := "0123456785"
+	for i := 0; i < len(valid); i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == valid[i] {
+				continue
+			}
+			typo := valid[:i] + string(d) + valid[i+1:]
+			field, message, err := nubanIssue(typo, "058")
+			assert.ErrorIs(t, err, ErrInvalidAccountNumber, typo)
+			assert.Equal(t, "account_number", field, typo)
+			assert.Contains(t, message, "likely mistyped or swapped", typo)
+		}
+	}
+
+	// Digits 4 and 5 swapped.
+	assert.ErrorIs(t, ValidateNUBAN("0124356785", "058"), ErrInvalidAccountNumber)
+}
+
+func TestLookupBank(t *testing.T) {
+	bank, ok := LookupBank("058")
+	assert.True(t, ok)
+	assert.Equal(t, Bank{Code: "058", Name: "Guaranty Trust Bank"}, bank)
+
+	_, ok = LookupBank("999")
+	assert.False(t, ok)
+
+	known := KnownBanks()
+	assert.NotEmpty(t, known)
+	for i := 1; i < len(known); i++ {
+		assert.Less(t, known[i-1].Code, known[i].Code)
+	}
+}
+
+func TestNUBANBodyValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   validator
+		fields []string
+	}{
+		{"resolve typo", ResolveAccountNumberBody{AccountNumber: "0123466785", BankCode: "058"}, []string{"account_number"}},
+		{"resolve short account", ResolveAccountNumberBody{AccountNumber: "012345678", BankCode: "058"}, []string{"account_number"}},
+		{"resolve unknown bank", ResolveAccountNumberBody{AccountNumber: "0123456789", BankCode: "999"}, nil},
+		{"resolve valid", ResolveAccountNumberBody{AccountNumber: "0123456785", BankCode: "058"}, nil},
+		{"ngn payout typo", CreatePayoutBody{AccountNumber: "0123456789", BankCode: "058", Currency: NGN, Amount: 100}, []string{"account_number"}},
+		{"ngn payout to unlisted bank", CreatePayoutBody{AccountNumber: "1234567890", BankCode: "50515", Currency: NGN, Amount: 100}, nil},
+		{"ngn payout to unlisted 6 digit code", CreatePayoutBody{AccountNumber: "1234567890", BankCode: "999999", Currency: NGN, Amount: 100}, nil},
+		{"usd payout", CreatePayoutBody{AccountNumber: "000123456789", BankCode: "021000021", Currency: USD, Amount: 100}, nil},
+		{"sender typo", CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "0123456735", BankCode: "058"}}, []string{"sender.account_number"}},
+		{"sender valid", CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "0123456785", BankCode: "058"}}, nil},
+		{"sender of unlisted bank", CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "9876543210", BankCode: "50515"}}, nil},
+		{"sender short account", CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "01234", BankCode: "058"}}, []string{"sender.account_number"}},
+		{"sender without bank", CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "0123456785"}}, []string{"sender.bank_code"}},
+		{"no sender", CreditWalletBody{Amount: 100}, nil},
+	}
+
+	for _, tt := range tests {
+		err := tt.body.Validate()
+		if tt.fields == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+
+		var verr *ValidationError
+		if assert.ErrorAs(t, err, &verr, tt.name) {
+			var fields []string
+			for _, f := range verr.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.fields, fields, tt.name)
+		}
+	}
+
+	err := CreditWalletBody{Amount: 100, Sender: CreditWalletSenderInput{AccountNumber: "01234", BankCode: "058"}}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestAccountTypoNotSent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	unexpected := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}
+	mux.HandleFunc("/payouts", unexpected)
+	mux.HandleFunc("/resolve-account-number", unexpected)
+
+	_, err := client.Payout.Create(context.Background(), &CreatePayoutBody{AccountNumber: "0124356785", BankCode: "058", Currency: NGN, Amount: 100})
+	assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+	assert.Contains(t, err.Error(), "likely mistyped or swapped")
+
+	_, err = client.Other.ResolveAccountNumber(context.Background(), ResolveAccountNumberBody{AccountNumber: "0123466785", BankCode: "058"})
+	assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+}
@@ -0,0 +1,238 @@
+package swervpay
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	liveBaseURL    = "https://api.swervpay.co/api/v1/"
+	sandboxBaseURL = "https://sandbox.swervpay.co/api/v1/"
+)
+
+// apiVersionSegment matches the version segment of an API path, e.g. "v1".
+var apiVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// ErrInvalidConfig is returned by New when the client configuration is invalid.
+var ErrInvalidConfig = errors.New("swervpay: invalid configuration")
+
+// Option configures a SwervpayClient created with New.
+type Option func(*clientOptions) error
+
+// clientOptions collects the settings applied by Options.
+type clientOptions struct {
+	config          *SwervpayClientOption
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	timeout         time.Duration
+	headers         map[string]string
+	userAgentSuffix string
+	middlewares     []Middleware
+	logger          *slog.Logger
+	metrics         MetricsCollector
+	limiter         *rateLimiter
+	breaker         *circuitBreaker
+	onSchemaDrift   func(SchemaDrift) error
+	skipValidation  bool
+}
+
+// WithCredentials sets the business id and secret key used to authenticate.
+func WithCredentials(businessID, secretKey string) Option {
+	return func(o *clientOptions) error {
+		o.config.BusinessID = businessID
+		o.config.SecretKey = secretKey
+		return nil
+	}
+}
+
+// WithSandbox points the client at the sandbox environment.
+func WithSandbox(sandbox bool) Option {
+	return func(o *clientOptions) error {
+		o.config.Sandbox = sandbox
+		return nil
+	}
+}
+
+// WithBaseURL overrides the base URL of the API.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		o.config.BaseURL = baseURL
+		return nil
+	}
+}
+
+// WithAPIVersion selects the version of the API, e.g. "v1", by replacing the
+// version segment of the base URL path, as in /api/v1/. A version of another
+// form, or a base URL without a version segment, leaves the base URL as is and
+// logs a warning.
+func WithAPIVersion(version string) Option {
+	return func(o *clientOptions) error {
+		o.config.Version = version
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests. The client is
+// copied, never modified, when other options need to adjust it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) error {
+		if client == nil {
+			return fmt.Errorf("%w: http client must not be nil", ErrInvalidConfig)
+		}
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithTransport sets the round tripper used by the HTTP client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		if transport == nil {
+			return fmt.Errorf("%w: transport must not be nil", ErrInvalidConfig)
+		}
+		o.transport = transport
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request attempt.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return fmt.Errorf("%w: timeout must not be negative", ErrInvalidConfig)
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithHeaders adds headers sent with every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *clientOptions) error {
+		if o.headers == nil {
+			o.headers = map[string]string{}
+		}
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the User-Agent header, e.g. "my-service/1.2".
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) error {
+		o.userAgentSuffix = suffix
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy for transient failures.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.config.Retry = policy
+		return nil
+	}
+}
+
+// WithTokenStore sets the store access tokens are shared through.
+func WithTokenStore(store TokenStore) Option {
+	return func(o *clientOptions) error {
+		o.config.TokenStore = store
+		return nil
+	}
+}
+
+// New creates a new SwervpayClient configured with opts.
+func New(opts ...Option) (*SwervpayClient, error) {
+	o := &clientOptions{config: &SwervpayClientOption{}}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.config.BusinessID == "" || o.config.SecretKey == "" {
+		return nil, fmt.Errorf("%w: business id and secret key are required", ErrInvalidConfig)
+	}
+
+	c, err := newClient(o.config, o)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseBaseURL validates the base URL and makes sure it ends with a slash, so
+// that resource paths resolve below it.
+func parseBaseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base URL %q: %v", ErrInvalidConfig, raw, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: base URL %q must be an absolute http(s) URL", ErrInvalidConfig, raw)
+	}
+
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return u, nil
+}
+
+// applyAPIVersion returns u with the last segment of its path, which must be a
+// version such as "v1", replaced by version. u is returned as is when version
+// is empty, and reported as not versioned when version is not of the form v1
+// or u has no version segment.
+func applyAPIVersion(u *url.URL, version string) (*url.URL, bool) {
+	if version == "" {
+		return u, true
+	}
+	if !apiVersionSegment.MatchString(version) {
+		return u, false
+	}
+
+	segments := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	if last := segments[len(segments)-1]; !apiVersionSegment.MatchString(last) {
+		return u, false
+	}
+	segments[len(segments)-1] = version
+
+	versioned := *u
+	versioned.Path = strings.Join(segments, "/") + "/"
+	return &versioned, true
+}
+
+// buildHTTPClient returns the HTTP client described by the options.
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	timeout := o.timeout
+	if timeout == 0 && o.config.Timeout > 0 {
+		timeout = time.Duration(o.config.Timeout) * time.Second
+	}
+
+	if o.httpClient == nil && o.transport == nil && timeout == 0 {
+		return http.DefaultClient
+	}
+
+	client := &http.Client{}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		client = &copied
+	}
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+	if timeout > 0 {
+		client.Timeout = timeout
+	}
+
+	return client
+}
@@ -0,0 +1,161 @@
+package swervpay
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewDefaults(t *testing.T) {
+	c, err := New(WithCredentials("biz_001", "sk_001"))
+	assert.NoError(t, err)
+	assert.Equal(t, c.BaseURL.String(), "https://api.swervpay.co/api/v1/")
+	assert.Same(t, c.client, http.DefaultClient)
+
+	c, err = New(WithCredentials("biz_001", "sk_001"), WithSandbox(true))
+	assert.NoError(t, err)
+	assert.Equal(t, c.BaseURL.String(), "https://sandbox.swervpay.co/api/v1/")
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	cases := [][]Option{
+		{},
+		{WithCredentials("biz_001", "")},
+		{WithCredentials("biz_001", "sk_001"), WithBaseURL("::not a url")},
+		{WithCredentials("biz_001", "sk_001"), WithBaseURL("api.swervpay.co/api/v1")},
+		{WithCredentials("biz_001", "sk_001"), WithTimeout(-time.Second)},
+		{WithCredentials("biz_001", "sk_001"), WithHTTPClient(nil)},
+		{WithCredentials("biz_001", "sk_001"), WithTransport(nil)},
+	}
+
+	for _, opts := range cases {
+		c, err := New(opts...)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Nil(t, c)
+	}
+}
+
+func TestNewSwervpayClientReportsInvalidBaseURL(t *testing.T) {
+	c := NewSwervpayClient(&SwervpayClientOption{BaseURL: "::not a url"})
+
+	_, err := c.Business.Get(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestNewSendsConfiguredHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth" {
+			_, _ = w.Write([]byte(`{"access_token":"token_1"}`))
+			return
+		}
+
+		assert.Equal(t, r.URL.Path, "/v1/business")
+		assert.Equal(t, r.Header.Get("X-Team"), "payments")
+		assert.Equal(t, r.Header.Get("User-Agent"), userAgent+" payouts-svc/1.0")
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(
+		WithCredentials("biz_001", "sk_001"),
+		WithBaseURL(server.URL+"/v1"),
+		WithHeaders(map[string]string{"X-Team": "payments"}),
+		WithUserAgentSuffix("payouts-svc/1.0"),
+	)
+	assert.NoError(t, err)
+
+	resp, err := c.Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, "biz_001")
+}
+
+func TestNewAPIVersion(t *testing.T) {
+	c, err := New(WithCredentials("biz_001", "sk_001"), WithAPIVersion("v2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.swervpay.co/api/v2/", c.BaseURL.String())
+
+	c, err = New(WithCredentials("biz_001", "sk_001"), WithSandbox(true), WithAPIVersion("v1"))
+	assert.NoError(t, err)
+	assert.Equal(t, sandboxBaseURL, c.BaseURL.String())
+
+	c, err = New(WithCredentials("biz_001", "sk_001"), WithBaseURL("https://proxy.example.com/swervpay/v1"), WithAPIVersion("v3"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://proxy.example.com/swervpay/v3/", c.BaseURL.String())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	c, err = New(WithCredentials("biz_001", "sk_001"), WithAPIVersion("2024-01-01"), WithLogger(logger))
+	assert.NoError(t, err)
+	assert.Equal(t, liveBaseURL, c.BaseURL.String())
+	assert.Contains(t, buf.String(), `version=2024-01-01`)
+}
+
+func TestAPIVersionWithUnversionedProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/swervpay/business", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	// The version cannot be applied, so the base URL is used as it was before versions were applied.
+	c := NewSwervpayClient(&SwervpayClientOption{BusinessID: "biz_001", SecretKey: "sk_001", BaseURL: server.URL + "/swervpay", Version: "v2"})
+	c.AccessToken = "token_001"
+	assert.Equal(t, server.URL+"/swervpay/", c.BaseURL.String())
+
+	business, err := c.Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "biz_001", business.ID)
+
+	_, err = New(WithCredentials("biz_001", "sk_001"), WithBaseURL(server.URL+"/swervpay"), WithAPIVersion("v2"), WithLogger(logger))
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "API version not applied")
+}
+
+func TestNewHTTPClientOptions(t *testing.T) {
+	base := &http.Client{Timeout: time.Minute}
+
+	var used bool
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	c, err := New(WithCredentials("biz_001", "sk_001"), WithHTTPClient(base), WithTransport(transport), WithTimeout(5*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, c.client.Timeout, 5*time.Second)
+	assert.NotNil(t, c.client.Transport)
+	assert.Equal(t, base.Timeout, time.Minute)
+	assert.Nil(t, base.Transport)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"token_1"}`))
+	}))
+	defer server.Close()
+
+	c, err = New(WithCredentials("biz_001", "sk_001"), WithBaseURL(server.URL), WithTransport(transport))
+	assert.NoError(t, err)
+	_, err = c.Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, used)
+}
+
+func TestNewSwervpayClientHonorsTimeout(t *testing.T) {
+	c := NewSwervpayClient(&SwervpayClientOption{Timeout: 7})
+	assert.Equal(t, c.client.Timeout, 7*time.Second)
+}
@@ -25,12 +25,24 @@ type ResolveAccountNumberBody struct {
 	BankCode      string `json:"bank_code"`      // BankCode is the code of the bank the account belongs to.
 }
 
+// Validate checks the account before the request is sent, catching a
+// mistyped account number of a bank in the embedded table offline.
+func (b ResolveAccountNumberBody) Validate() error {
+	v := newValidation("ResolveAccountNumberBody")
+	hasAccount := v.required("account_number", b.AccountNumber)
+	hasBank := v.required("bank_code", b.BankCode)
+	if hasAccount && hasBank {
+		v.nuban("", b.AccountNumber, b.BankCode)
+	}
+	return v.result()
+}
+
 // OtherInt is an interface for interacting with the Swervpay API.
 type OtherInt interface {
 	// Banks retrieves a list of all banks in the Swervpay system.
-	Banks(ctx context.Context) ([]*Bank, error)
+	Banks(ctx context.Context, opts ...CallOption) ([]*Bank, error)
 	// ResolveAccountNumber resolves an account number in the Swervpay system.
-	ResolveAccountNumber(ctx context.Context, body ResolveAccountNumberBody) (*ResolveAccountNumber, error)
+	ResolveAccountNumber(ctx context.Context, body ResolveAccountNumberBody, opts ...CallOption) (*ResolveAccountNumber, error)
 }
 
 // OtherIntImpl is an implementation of the OtherInt interface.
@@ -43,9 +55,9 @@ var _ OtherInt = &OtherIntImpl{}
 
 // Banks retrieves a list of all banks in the Swervpay system.
 // https://docs.swervpay.co/api-reference/others/get-banks
-func (o OtherIntImpl) Banks(ctx context.Context) ([]*Bank, error) {
+func (o OtherIntImpl) Banks(ctx context.Context, opts ...CallOption) ([]*Bank, error) {
 
-	req, err := o.client.NewRequest(ctx, http.MethodGet, "banks", nil)
+	req, err := o.client.NewRequest(ctx, http.MethodGet, "banks", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,9 +75,9 @@ func (o OtherIntImpl) Banks(ctx context.Context) ([]*Bank, error) {
 
 // ResolveAccountNumber resolves an account number in the Swervpay system.
 // https://docs.swervpay.co/api-reference/others/resolve-account-number
-func (o OtherIntImpl) ResolveAccountNumber(ctx context.Context, body ResolveAccountNumberBody) (*ResolveAccountNumber, error) {
+func (o OtherIntImpl) ResolveAccountNumber(ctx context.Context, body ResolveAccountNumberBody, opts ...CallOption) (*ResolveAccountNumber, error) {
 
-	req, err := o.client.NewRequest(ctx, http.MethodPost, "resolve-account-number", body)
+	req, err := o.client.NewRequest(ctx, http.MethodPost, "resolve-account-number", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +76,8 @@ func TestResolveAccountNumber(t *testing.T) {
 		BankCode:      "058",
 	}
 
-	resp, err := client.Other.ResolveAccountNumber(context.Background(), req)
+	// The fixture account is not a valid NUBAN of GTBank, so the offline check is skipped.
+	resp, err := client.Other.ResolveAccountNumber(context.Background(), req, WithCallValidation(false))
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.AccountNumber, "1234567890")
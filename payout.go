@@ -6,13 +6,29 @@ import (
 )
 
 // CreatePayoutBody represents the request body for creating a payout.
+// Amount can be set from an exact decimal with Amount.Float64, which is sent
+// with the same digits for amounts of up to 15 significant digits.
 type CreatePayoutBody struct {
-	Reference     string  `json:"reference"`      // Unique reference for the payout
-	AccountNumber string  `json:"account_number"` // Account number to send the payout to
-	Narration     string  `json:"narration"`      // Description of the payout
-	BankCode      string  `json:"bank_code"`      // Code of the bank for the account
-	Currency      string  `json:"currency"`       // Currency of the payout
-	Amount        float64 `json:"amount"`         // Amount of the payout
+	Reference     string   `json:"reference"`      // Unique reference for the payout
+	AccountNumber string   `json:"account_number"` // Account number to send the payout to
+	Narration     string   `json:"narration"`      // Description of the payout
+	BankCode      string   `json:"bank_code"`      // Code of the bank for the account
+	Currency      Currency `json:"currency"`       // Currency of the payout
+	Amount        float64  `json:"amount"`         // Amount of the payout
+}
+
+// Validate checks the payout before it is sent. NGN payouts to a bank in the
+// embedded CBN table must go to a valid NUBAN; other accounts are left to the API.
+func (b CreatePayoutBody) Validate() error {
+	v := newValidation("CreatePayoutBody")
+	hasAccount := v.required("account_number", b.AccountNumber)
+	hasBank := v.required("bank_code", b.BankCode)
+	if hasAccount && hasBank && oneOf(b.Currency, NGN) {
+		v.nuban("", b.AccountNumber, b.BankCode)
+	}
+	v.currency("currency", b.Currency, true)
+	v.positive("amount", b.Amount)
+	return v.result()
 }
 
 // CreatePayoutResponse represents the response from creating a payout.
@@ -20,14 +36,16 @@ type CreatePayoutResponse struct {
 	Reference string `json:"reference"` // Unique reference for the payout
 	ID        string `json:"id"`        // ID of the payout
 	Message   string `json:"message"`   // Message indicating the status of the payout
+
+	IdempotencyKey string `json:"-"` // Idempotency key the payout was sent with
 }
 
 // PayoutInt is an interface for managing payouts.
 type PayoutInt interface {
 	// Get retrieves a payout by its ID.
-	Get(ctx context.Context, id string) (*Transaction, error)
+	Get(ctx context.Context, id string, opts ...CallOption) (*Transaction, error)
 	// Create creates a new payout with the provided body.
-	Create(ctx context.Context, body *CreatePayoutBody) (*CreatePayoutResponse, error)
+	Create(ctx context.Context, body *CreatePayoutBody, opts ...CallOption) (*CreatePayoutResponse, error)
 }
 
 // PayoutIntImpl is an implementation of the PayoutInt interface.
@@ -40,9 +58,9 @@ var _ PayoutInt = &PayoutIntImpl{}
 
 // Get retrieves a payout by its ID.
 // https://docs.swervpay.co/api-reference/payouts/get
-func (p PayoutIntImpl) Get(ctx context.Context, id string) (*Transaction, error) {
+func (p PayoutIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Transaction, error) {
 	// Create a new request to get a payout.
-	req, err := p.client.NewRequest(ctx, http.MethodGet, "payouts/"+id, nil)
+	req, err := p.client.NewRequest(ctx, http.MethodGet, "payouts/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,10 +79,18 @@ func (p PayoutIntImpl) Get(ctx context.Context, id string) (*Transaction, error)
 }
 
 // Create creates a new payout with the provided body.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// body has a Reference, which the API deduplicates payouts by.
 // https://docs.swervpay.co/api-reference/payouts/create
-func (p PayoutIntImpl) Create(ctx context.Context, body *CreatePayoutBody) (*CreatePayoutResponse, error) {
+func (p PayoutIntImpl) Create(ctx context.Context, body *CreatePayoutBody, opts ...CallOption) (*CreatePayoutResponse, error) {
+	reference := ""
+	if body != nil {
+		reference = body.Reference
+	}
+
 	// Create a new request to create a payout.
-	req, err := p.client.NewRequest(ctx, http.MethodPost, "payouts", body)
+	req, err := p.client.NewRequest(ctx, http.MethodPost, "payouts", body, idempotent(opts, reference)...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +104,8 @@ func (p PayoutIntImpl) Create(ctx context.Context, body *CreatePayoutBody) (*Cre
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	// Return the response from creating the payout.
 	return response, nil
 }
@@ -0,0 +1,181 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrUnknownBusiness may be returned by a CredentialProvider that has no
+// credentials for a business.
+var ErrUnknownBusiness = errors.New("swervpay: unknown business")
+
+// CredentialProvider loads the secret key of a business.
+type CredentialProvider interface {
+	// SecretKey returns the secret key of the business with the given id.
+	SecretKey(ctx context.Context, businessID string) (string, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface.
+type CredentialProviderFunc func(ctx context.Context, businessID string) (string, error)
+
+// SecretKey calls f(ctx, businessID).
+func (f CredentialProviderFunc) SecretKey(ctx context.Context, businessID string) (string, error) {
+	return f(ctx, businessID)
+}
+
+// ClientPoolConfig configures a ClientPool.
+type ClientPoolConfig struct {
+	Credentials CredentialProvider // Source of the secret key of every business. Required.
+	Options     []Option           // Options applied to every client, e.g. WithSandbox or WithMetrics.
+	IdleTimeout time.Duration      // Time after which an unused client is evicted; 30 minutes when zero.
+
+	// Transport is shared by every client of the pool, so that connections
+	// to Swervpay are reused across businesses. A clone of
+	// http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+}
+
+// ClientPool lazily creates and caches one SwervpayClient per business.
+// Clients share a transport, and clients not requested for longer than the
+// idle timeout are evicted. It is safe for concurrent use.
+type ClientPool struct {
+	config ClientPoolConfig
+	now    func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*pooledClient
+	lastSweep time.Time
+}
+
+// pooledClient is a cache entry. ready is closed once client or err is set.
+type pooledClient struct {
+	ready    chan struct{}
+	client   *SwervpayClient
+	err      error
+	lastUsed time.Time
+}
+
+// NewClientPool creates a ClientPool.
+func NewClientPool(config ClientPoolConfig) (*ClientPool, error) {
+	if config.Credentials == nil {
+		return nil, fmt.Errorf("%w: client pool requires a credential provider", ErrInvalidConfig)
+	}
+	if config.IdleTimeout < 0 {
+		return nil, fmt.Errorf("%w: idle timeout must not be negative", ErrInvalidConfig)
+	}
+
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = 30 * time.Minute
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	return &ClientPool{config: config, now: time.Now, clients: map[string]*pooledClient{}}, nil
+}
+
+// Client returns the client of a business, creating it on first use. Concurrent
+// callers asking for the same business wait for a single client to be created.
+func (p *ClientPool) Client(ctx context.Context, businessID string) (*SwervpayClient, error) {
+	if businessID == "" {
+		return nil, fmt.Errorf("%w: business id is required", ErrInvalidConfig)
+	}
+
+	for {
+		p.mu.Lock()
+		now := p.now()
+		p.sweep(now)
+
+		entry, ok := p.clients[businessID]
+		if !ok {
+			entry = &pooledClient{ready: make(chan struct{}), lastUsed: now}
+			p.clients[businessID] = entry
+			p.mu.Unlock()
+
+			p.create(ctx, businessID, entry)
+			return entry.client, entry.err
+		}
+		entry.lastUsed = now
+		p.mu.Unlock()
+
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if entry.err == nil {
+			return entry.client, nil
+		}
+		// The creation failed and its entry was removed; try again with this caller's context.
+	}
+}
+
+// create builds the client of entry and publishes the result.
+func (p *ClientPool) create(ctx context.Context, businessID string, entry *pooledClient) {
+	defer close(entry.ready)
+
+	entry.client, entry.err = p.newClient(ctx, businessID)
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.clients[businessID] == entry {
+			delete(p.clients, businessID)
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *ClientPool) newClient(ctx context.Context, businessID string) (*SwervpayClient, error) {
+	secretKey, err := p.config.Credentials.SecretKey(ctx, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("swervpay: loading credentials of business %q: %w", businessID, err)
+	}
+
+	opts := make([]Option, 0, len(p.config.Options)+2)
+	opts = append(opts, p.config.Options...)
+	opts = append(opts, WithCredentials(businessID, secretKey), WithTransport(p.config.Transport))
+
+	return New(opts...)
+}
+
+// Evict removes the client of a business, e.g. after its secret key was
+// rotated. The next call to Client creates a new one.
+func (p *ClientPool) Evict(businessID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, businessID)
+}
+
+// Len returns the number of businesses with a cached client.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sweep(p.now())
+	return len(p.clients)
+}
+
+// sweep evicts idle clients. It runs at most once per half idle timeout and
+// must be called with p.mu held.
+func (p *ClientPool) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.config.IdleTimeout/2 {
+		return
+	}
+	p.lastSweep = now
+
+	for id, entry := range p.clients {
+		select {
+		case <-entry.ready:
+		default:
+			continue // Still being created.
+		}
+		if now.Sub(entry.lastUsed) >= p.config.IdleTimeout {
+			delete(p.clients, id)
+		}
+	}
+}
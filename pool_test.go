@@ -0,0 +1,159 @@
+package swervpay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPoolServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if secret != "sk_"+id {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token_` + id + `","token":{"type":"Bearer"}}`))
+	})
+	mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"` + r.Header.Get("Authorization") + `"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestClientPool(t *testing.T) {
+	server := newPoolServer(t)
+	defer server.Close()
+
+	var lookups, roundTrips int32
+	var buf bytes.Buffer
+	metrics := NewInMemoryMetrics()
+
+	pool, err := NewClientPool(ClientPoolConfig{
+		Credentials: CredentialProviderFunc(func(ctx context.Context, businessID string) (string, error) {
+			atomic.AddInt32(&lookups, 1)
+			return "sk_" + businessID, nil
+		}),
+		Options: []Option{
+			WithBaseURL(server.URL),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithMetrics(metrics),
+		},
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&roundTrips, 1)
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	clients := make([]*SwervpayClient, 8)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = pool.Client(context.Background(), "biz_001")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, atomic.LoadInt32(&lookups), int32(1))
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+
+	other, err := pool.Client(context.Background(), "biz_002")
+	assert.NoError(t, err)
+	assert.NotSame(t, clients[0], other)
+	assert.Equal(t, pool.Len(), 2)
+
+	business, err := clients[0].Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, business.Name, "Bearer token_biz_001")
+
+	business, err = other.Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, business.Name, "Bearer token_biz_002")
+
+	// Two authentications and two calls, all through the shared transport.
+	assert.Equal(t, atomic.LoadInt32(&roundTrips), int32(4))
+
+	lines := decodeLogLines(t, &buf)
+	if assert.Len(t, lines, 4) {
+		assert.Equal(t, lines[1]["business_id"], "biz_001")
+		assert.Equal(t, lines[3]["business_id"], "biz_002")
+	}
+
+	snapshot := metrics.Snapshot()
+	if assert.Len(t, snapshot, 4) {
+		assert.Equal(t, snapshot[0].BusinessID, "biz_001")
+		assert.Equal(t, snapshot[2].BusinessID, "biz_002")
+	}
+}
+
+func TestClientPoolEvictsIdleClients(t *testing.T) {
+	now := time.Unix(0, 0)
+	pool, err := NewClientPool(ClientPoolConfig{
+		Credentials: CredentialProviderFunc(func(ctx context.Context, businessID string) (string, error) {
+			return "sk_" + businessID, nil
+		}),
+		IdleTimeout: time.Minute,
+	})
+	assert.NoError(t, err)
+	pool.now = func() time.Time { return now }
+
+	first, err := pool.Client(context.Background(), "biz_001")
+	assert.NoError(t, err)
+	_, err = pool.Client(context.Background(), "biz_002")
+	assert.NoError(t, err)
+
+	now = now.Add(45 * time.Second)
+	again, err := pool.Client(context.Background(), "biz_001")
+	assert.NoError(t, err)
+	assert.Same(t, first, again)
+
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, pool.Len(), 1)
+
+	pool.Evict("biz_001")
+	again, err = pool.Client(context.Background(), "biz_001")
+	assert.NoError(t, err)
+	assert.NotSame(t, first, again)
+}
+
+func TestClientPoolCredentialErrorsAreNotCached(t *testing.T) {
+	fail := true
+	pool, err := NewClientPool(ClientPoolConfig{
+		Credentials: CredentialProviderFunc(func(ctx context.Context, businessID string) (string, error) {
+			if fail {
+				return "", ErrUnknownBusiness
+			}
+			return "sk_" + businessID, nil
+		}),
+	})
+	assert.NoError(t, err)
+
+	_, err = pool.Client(context.Background(), "biz_001")
+	assert.True(t, errors.Is(err, ErrUnknownBusiness))
+	assert.Equal(t, pool.Len(), 0)
+
+	fail = false
+	c, err := pool.Client(context.Background(), "biz_001")
+	assert.NoError(t, err)
+	assert.Equal(t, c.Config.BusinessID, "biz_001")
+}
+
+func TestNewClientPoolInvalidConfig(t *testing.T) {
+	_, err := NewClientPool(ClientPoolConfig{})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,181 @@
+package swervpay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token-bucket budget.
+type RateLimit struct {
+	Rate  float64 // Sustained requests per second.
+	Burst int     // Requests allowed at once; at least 1.
+}
+
+// RateLimitConfig configures client-side rate limiting. Requests wait for
+// capacity, or for their context to be done, instead of being rejected.
+type RateLimitConfig struct {
+	Global *RateLimit // Budget shared by every request; none when nil.
+
+	// Groups holds stricter budgets for route groups, keyed by templated route
+	// prefix such as "payouts" or "fx/exchange". A request counts against the
+	// longest matching group in addition to the global budget.
+	Groups map[string]RateLimit
+
+	MaxInFlight int // Maximum concurrent requests; unlimited when zero.
+}
+
+// WithRateLimit enables client-side rate limiting.
+func WithRateLimit(config RateLimitConfig) Option {
+	return func(o *clientOptions) error {
+		limiter, err := newRateLimiter(config)
+		if err != nil {
+			return err
+		}
+		o.limiter = limiter
+		return nil
+	}
+}
+
+// rateLimiter applies the global and group budgets and the in-flight cap.
+type rateLimiter struct {
+	global   *tokenBucket
+	groups   []groupBucket // Sorted by descending prefix length so the longest match wins.
+	inFlight chan struct{}
+}
+
+type groupBucket struct {
+	prefix string
+	bucket *tokenBucket
+}
+
+func newRateLimiter(config RateLimitConfig) (*rateLimiter, error) {
+	l := &rateLimiter{}
+
+	if config.Global != nil {
+		b, err := newTokenBucket("global", *config.Global)
+		if err != nil {
+			return nil, err
+		}
+		l.global = b
+	}
+
+	for prefix, limit := range config.Groups {
+		prefix = strings.Trim(prefix, "/")
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: rate limit group must not be empty", ErrInvalidConfig)
+		}
+
+		b, err := newTokenBucket(prefix, limit)
+		if err != nil {
+			return nil, err
+		}
+		l.groups = append(l.groups, groupBucket{prefix: prefix, bucket: b})
+	}
+	sort.Slice(l.groups, func(i, j int) bool { return len(l.groups[i].prefix) > len(l.groups[j].prefix) })
+
+	if config.MaxInFlight < 0 {
+		return nil, fmt.Errorf("%w: max in-flight requests must not be negative", ErrInvalidConfig)
+	}
+	if config.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+
+	return l, nil
+}
+
+// acquire waits until a request to route may be sent. The returned function
+// releases the in-flight slot and must be called once the attempt is done.
+func (l *rateLimiter) acquire(ctx context.Context, route string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if b := l.group(route); b != nil {
+		if err := b.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.global != nil {
+		if err := l.global.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// group returns the bucket of the longest group matching route.
+func (l *rateLimiter) group(route string) *tokenBucket {
+	for _, g := range l.groups {
+		if routeHasPrefix(route, g.prefix) {
+			return g.bucket
+		}
+	}
+	return nil
+}
+
+// routeHasPrefix reports whether route equals prefix or continues it with a "/".
+func routeHasPrefix(route, prefix string) bool {
+	return route == prefix || strings.HasPrefix(route, prefix+"/")
+}
+
+// tokenBucket is a token-bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(name string, limit RateLimit) (*tokenBucket, error) {
+	if limit.Rate <= 0 {
+		return nil, fmt.Errorf("%w: rate limit %q must have a positive rate", ErrInvalidConfig, name)
+	}
+
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{rate: limit.Rate, burst: float64(burst), tokens: float64(burst), last: time.Now(), now: time.Now}, nil
+}
+
+// wait takes a token, sleeping until one is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+
+		now := b.now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package swervpay
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b, err := newTokenBucket("test", RateLimit{Rate: 10, Burst: 2})
+	assert.NoError(t, err)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	ctx := context.Background()
+	assert.NoError(t, b.wait(ctx))
+	assert.NoError(t, b.wait(ctx))
+
+	// The bucket is empty; a caller with a short deadline gives up.
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.wait(short), context.DeadlineExceeded)
+
+	now = now.Add(100 * time.Millisecond)
+	assert.NoError(t, b.wait(ctx))
+}
+
+func TestRateLimiterGroups(t *testing.T) {
+	l, err := newRateLimiter(RateLimitConfig{
+		Groups: map[string]RateLimit{
+			"payouts":     {Rate: 1},
+			"fx":          {Rate: 2},
+			"fx/exchange": {Rate: 3},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Same(t, l.group("payouts"), l.group("payouts/{id}"))
+	assert.Equal(t, l.group("fx/exchange").rate, 3.0)
+	assert.Equal(t, l.group("fx/rate").rate, 2.0)
+	assert.Nil(t, l.group("payoutsx"))
+	assert.Nil(t, l.group("cards/{id}"))
+}
+
+func TestRateLimiterInvalidConfig(t *testing.T) {
+	for _, config := range []RateLimitConfig{
+		{Global: &RateLimit{Rate: 0}},
+		{Groups: map[string]RateLimit{"payouts": {Rate: -1}}},
+		{Groups: map[string]RateLimit{"/": {Rate: 1}}},
+		{MaxInFlight: -1},
+	} {
+		_, err := newRateLimiter(config)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	}
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter, err := newRateLimiter(RateLimitConfig{Groups: map[string]RateLimit{"payouts": {Rate: 20, Burst: 1}}})
+	assert.NoError(t, err)
+	client.limiter = limiter
+
+	mux.HandleFunc("/payouts/payout_001", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"payout_001"}`))
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.Payout.Get(context.Background(), "payout_001")
+		assert.NoError(t, err)
+	}
+
+	// One request from the burst, then two more at 20 per second.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestMaxInFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter, err := newRateLimiter(RateLimitConfig{MaxInFlight: 2})
+	assert.NoError(t, err)
+	client.limiter = limiter
+
+	var current, peak int32
+	mux.HandleFunc("/customers/cust_001", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		_, _ = w.Write([]byte(`{"id":"cust_001"}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Customer.Get(context.Background(), "cust_001")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter, err := newRateLimiter(RateLimitConfig{Global: &RateLimit{Rate: 0.1}})
+	assert.NoError(t, err)
+	client.limiter = limiter
+
+	mux.HandleFunc("/banks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	_, err = client.Other.Banks(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Other.Banks(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,157 @@
+package swervpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// redactMode describes how the value of a sensitive field is masked.
+type redactMode int
+
+const (
+	redactAll       redactMode = iota // Replace the whole value.
+	redactKeepLast4                   // Keep the last four characters, e.g. of a PAN.
+)
+
+// sensitiveFields maps normalised field names to the way their values are masked.
+var sensitiveFields = map[string]redactMode{
+	"cardnumber":       redactKeepLast4,
+	"pan":              redactKeepLast4,
+	"accountnumber":    redactKeepLast4,
+	"cvv":              redactAll,
+	"cvv2":             redactAll,
+	"pin":              redactAll,
+	"bvn":              redactAll,
+	"directorbvn":      redactAll,
+	"nin":              redactAll,
+	"documentnumber":   redactAll,
+	"passport":         redactAll,
+	"encrypteddetails": redactAll,
+	"secretkey":        redactAll,
+	"accesstoken":      redactAll,
+	"token":            redactAll,
+	"password":         redactAll,
+	"authorization":    redactAll,
+}
+
+var (
+	// panPattern matches card numbers in free text.
+	panPattern = regexp.MustCompile(`\b\d{13,19}\b`)
+	// idPattern matches account numbers, BVNs and NINs in free text.
+	idPattern = regexp.MustCompile(`\b\d{10,11}\b`)
+)
+
+// RedactBody masks card numbers, CVVs, BVNs, NINs, account numbers, secrets
+// and tokens in a request or response body so that it can be logged. JSON
+// bodies are masked field by field, with long digit sequences masked in the
+// other strings; anything else has long digit sequences masked.
+func RedactBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if out, err := json.Marshal(redactValue("", v)); err == nil {
+			return out
+		}
+	}
+
+	return []byte(redactText(string(body)))
+}
+
+// RedactHeader returns a copy of h with credentials and cookies masked. The
+// scheme of the Authorization header is kept so logs still show how a request
+// authenticated.
+func RedactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for k, values := range out {
+		if strings.EqualFold(k, "Cookie") || strings.EqualFold(k, "Set-Cookie") {
+			for i := range values {
+				values[i] = redacted
+			}
+			continue
+		}
+		if _, ok := sensitiveFields[normaliseField(k)]; !ok && !strings.EqualFold(k, "Proxy-Authorization") {
+			continue
+		}
+		for i, v := range values {
+			if scheme, _, ok := strings.Cut(v, " "); ok {
+				values[i] = scheme + " " + redacted
+			} else {
+				values[i] = redacted
+			}
+		}
+	}
+	return out
+}
+
+// redactValue walks a decoded JSON value, masking sensitive fields.
+func redactValue(key string, v interface{}) interface{} {
+	if mode, ok := sensitiveFields[normaliseField(key)]; ok && v != nil {
+		switch t := v.(type) {
+		case string:
+			return mask(t, mode)
+		case json.Number:
+			return mask(t.String(), mode)
+		case map[string]interface{}, []interface{}:
+			// Structured values under a sensitive key are masked recursively below.
+		default:
+			return redacted
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			t[k] = redactValue(k, child)
+		}
+		return t
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactValue(key, child)
+		}
+		return t
+	case string:
+		return redactText(t)
+	default:
+		return v
+	}
+}
+
+// redactText masks digit sequences that look like card, account or identity numbers.
+func redactText(s string) string {
+	s = panPattern.ReplaceAllStringFunc(s, func(m string) string { return mask(m, redactKeepLast4) })
+	return idPattern.ReplaceAllStringFunc(s, func(m string) string { return mask(m, redactKeepLast4) })
+}
+
+func mask(s string, mode redactMode) string {
+	if mode == redactKeepLast4 && len(s) > 4 {
+		return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+	}
+	if s == "" {
+		return s
+	}
+	return redacted
+}
+
+// normaliseField lower-cases a field name and drops separators, so that
+// "card_number", "cardNumber" and "Card-Number" compare equal.
+func normaliseField(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r != '_' && r != '-' && r != ' ' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package swervpay
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBodyJSON(t *testing.T) {
+	body := []byte(`{
+		"card_number": "5399831234567890",
+		"cvv": "123",
+		"expiry": "12/27",
+		"account_number": "0123456789",
+		"information": {"bvn": "22212345678", "city": "Lagos"},
+		"nin": 12345678901,
+		"access_token": "eyJhbGciOi",
+		"narration": "refund for 4111111111111111",
+		"detail": "BVN 22212345678 does not match account 0123456789",
+		"amount": 1000.5
+	}`)
+
+	var out map[string]interface{}
+	assert.NoError(t, json.Unmarshal(RedactBody(body), &out))
+
+	assert.Equal(t, out["card_number"], "************7890")
+	assert.Equal(t, out["cvv"], redacted)
+	assert.Equal(t, out["expiry"], "12/27")
+	assert.Equal(t, out["account_number"], "******6789")
+	assert.Equal(t, out["information"], map[string]interface{}{"bvn": redacted, "city": "Lagos"})
+	assert.Equal(t, out["nin"], redacted)
+	assert.Equal(t, out["access_token"], redacted)
+	assert.Equal(t, out["narration"], "refund for ************1111")
+	assert.Equal(t, out["detail"], "BVN *******5678 does not match account ******6789")
+	assert.Equal(t, out["amount"], 1000.5)
+}
+
+func TestRedactBodyCamelCaseAndArrays(t *testing.T) {
+	out := string(RedactBody([]byte(`[{"cardNumber":"5399831234567890","directorBvn":"22212345678"}]`)))
+	assert.NotContains(t, out, "5399831234567890")
+	assert.NotContains(t, out, "22212345678")
+}
+
+func TestRedactBodyText(t *testing.T) {
+	out := string(RedactBody([]byte("card 5399831234567890 bvn 22212345678 code 044")))
+	assert.Equal(t, out, "card ************7890 bvn *******5678 code 044")
+}
+
+func TestRedactHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer eyJhbGciOi")
+	h.Set("Content-Type", "application/json")
+	h.Set("Cookie", "session=abc123; theme=dark")
+	h.Add("Set-Cookie", "session=abc123; HttpOnly")
+
+	out := RedactHeader(h)
+	assert.Equal(t, out.Get("Authorization"), "Bearer "+redacted)
+	assert.Equal(t, out.Get("Cookie"), redacted)
+	assert.Equal(t, out.Values("Set-Cookie"), []string{redacted})
+	assert.Equal(t, out.Get("Content-Type"), "application/json")
+	assert.Equal(t, h.Get("Authorization"), "Bearer eyJhbGciOi")
+}
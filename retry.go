@@ -0,0 +1,157 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key of a call.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// OnBehalfOfHeader is the request header carrying the business a call is made
+// on behalf of, see WithOnBehalfOf.
+const OnBehalfOfHeader = "X-On-Behalf-Of"
+
+// RetryPolicy configures how the client retries transient failures.
+type RetryPolicy struct {
+	MaxAttempts int                // Total attempts including the first one. Values below 2 disable retries.
+	BaseBackoff time.Duration      // Backoff before the first retry, doubled on every subsequent retry.
+	MaxBackoff  time.Duration      // Upper bound for a single backoff, including values taken from Retry-After.
+	Jitter      float64            // Fraction (0-1) of each backoff that is randomised.
+	OnAttempt   func(RetryAttempt) // Optional hook invoked after every attempt.
+}
+
+// RetryAttempt describes a single attempt of a request, as reported to RetryPolicy.OnAttempt.
+type RetryAttempt struct {
+	Attempt    int           // Attempt number, starting at 1.
+	Method     string        // HTTP method of the request.
+	Path       string        // URL path of the request.
+	StatusCode int           // Response status code, zero when the request failed before a response.
+	Err        error         // Transport error, if any.
+	Retrying   bool          // Whether another attempt will be made.
+	Delay      time.Duration // Wait before the next attempt, when Retrying is true.
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// retryableStatus reports whether a response status indicates a transient failure.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableRequest reports whether a request may safely be sent more than once.
+// Safe methods always qualify; anything else must carry an idempotency key
+// passed with WithIdempotencyKey or be marked with WithRetryableCall.
+func retryableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return settingsFromRequest(req).retryable
+}
+
+// next decides whether the attempt should be retried and how long to wait first.
+func (p *RetryPolicy) next(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || !retryableRequest(req) {
+		return 0, false
+	}
+
+	if err != nil {
+		// The caller gave up; retrying would only fail again.
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	if !retryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			d = p.MaxBackoff
+		}
+		return d, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential backoff for the given attempt with jitter applied.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.BaseBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		j := math.Min(p.Jitter, 1)
+		d = d*(1-j) + rand.Float64()*d*j
+	}
+
+	return time.Duration(d)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
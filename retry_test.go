@@ -0,0 +1,203 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(attempts *[]RetryAttempt) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		OnAttempt: func(a RetryAttempt) {
+			*attempts = append(*attempts, a)
+		},
+	}
+}
+
+func TestRetryTransientGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts []RetryAttempt
+	client.Config.Retry = testRetryPolicy(&attempts)
+
+	var calls int32
+	mux.HandleFunc("/transactions/txn_001", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(&Transaction{ID: "txn_001"})
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	resp, err := client.Transaction.Get(context.Background(), "txn_001")
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, "txn_001")
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(3))
+	if assert.Len(t, attempts, 3) {
+		assert.True(t, attempts[0].Retrying)
+		assert.Equal(t, attempts[0].StatusCode, http.StatusServiceUnavailable)
+		assert.False(t, attempts[2].Retrying)
+		assert.Equal(t, attempts[2].StatusCode, http.StatusOK)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts []RetryAttempt
+	client.Config.Retry = testRetryPolicy(&attempts)
+
+	mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := client.Card.Get(context.Background(), "card_001")
+
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, apiErr.StatusCode, http.StatusBadGateway)
+	}
+	assert.Len(t, attempts, 3)
+}
+
+func TestRetrySkipsUnsafePost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts []RetryAttempt
+	client.Config.Retry = testRetryPolicy(&attempts)
+
+	var calls int32
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "payouts", &CreatePayoutBody{}, WithCallValidation(false))
+	assert.NoError(t, err)
+
+	_, err = client.Perform(req, nil)
+	assert.Error(t, err)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(1))
+
+	// Nor is a payout without a reference, whose key was generated.
+	_, err = client.Payout.Create(context.Background(), &CreatePayoutBody{AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100})
+	assert.Error(t, err)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestRetryPostWithIdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts []RetryAttempt
+	client.Config.Retry = testRetryPolicy(&attempts)
+
+	var keys []string
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"Card funded"}`))
+	})
+
+	_, err := client.Card.Fund(context.Background(), "card_001", &FundOrWithdrawCardBody{Amount: 10}, WithIdempotencyKey("key_001"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key_001", "key_001"}, keys)
+	assert.Len(t, attempts, 2)
+}
+
+func TestRetryPayoutWithReference(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts []RetryAttempt
+	client.Config.Retry = testRetryPolicy(&attempts)
+
+	var calls int32
+	mux.HandleFunc("/payouts", func(w http.ResponseWriter, r *http.Request) {
+		var body CreatePayoutBody
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, body.Reference, "ref_001")
+		assert.Equal(t, r.Header.Get(IdempotencyKeyHeader), "key_001")
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"reference":"ref_001","id":"payout_001"}`))
+	})
+
+	ret, err := client.Payout.Create(context.Background(), &CreatePayoutBody{Reference: "ref_001", AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100}, WithIdempotencyKey("key_001"))
+	assert.NoError(t, err)
+	assert.Equal(t, ret.ID, "payout_001")
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Minute}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"7"}}}
+	delay, retry := policy.next(req, resp, nil, 1)
+	assert.True(t, retry)
+	assert.Equal(t, delay, 7*time.Second)
+
+	policy.MaxBackoff = 2 * time.Second
+	delay, _ = policy.next(req, resp, nil, 1)
+	assert.Equal(t, delay, 2*time.Second)
+
+	_, retry = policy.next(req, resp, nil, 3)
+	assert.False(t, retry)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		expected := 100 * time.Millisecond << (attempt - 1)
+		if expected > time.Second {
+			expected = time.Second
+		}
+		d := policy.backoff(attempt)
+		assert.GreaterOrEqual(t, d, expected/2)
+		assert.LessOrEqual(t, d, expected)
+	}
+}
+
+func TestRetryRespectsContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Config.Retry = &RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Second, MaxBackoff: time.Second}
+
+	mux.HandleFunc("/wallets/wal_001", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Wallet.Get(ctx, "wal_001")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,58 @@
+package swervpay
+
+import "strings"
+
+// staticRouteSegments lists the path segments of the Swervpay API that are
+// not identifiers. Any other segment is templated as {id}.
+var staticRouteSegments = map[string]bool{
+	"auth":                   true,
+	"banks":                  true,
+	"bills":                  true,
+	"blacklist":              true,
+	"business":               true,
+	"cards":                  true,
+	"categories":             true,
+	"collections":            true,
+	"credit":                 true,
+	"customers":              true,
+	"exchange":               true,
+	"freeze":                 true,
+	"fund":                   true,
+	"fx":                     true,
+	"items":                  true,
+	"kyc":                    true,
+	"payouts":                true,
+	"rate":                   true,
+	"regularize":             true,
+	"resolve-account-number": true,
+	"retry":                  true,
+	"terminate":              true,
+	"test":                   true,
+	"transactions":           true,
+	"unfreeze":               true,
+	"update":                 true,
+	"validate":               true,
+	"wallets":                true,
+	"webhook":                true,
+	"withdraw":               true,
+}
+
+// routeTemplate turns a request path into a route with identifiers replaced
+// by {id}, e.g. "/api/v1/cards/card_123/fund" becomes "cards/{id}/fund".
+// basePath is the path of the client's base URL and is stripped first.
+func routeTemplate(basePath, path string) string {
+	path = strings.TrimPrefix(path, basePath)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if !staticRouteSegments[s] {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
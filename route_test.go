@@ -0,0 +1,25 @@
+package swervpay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/cards/card_123/fund":                     "cards/{id}/fund",
+		"/api/v1/cards/card_123/transactions/txn_1":       "cards/{id}/transactions/{id}",
+		"/api/v1/payouts":                                 "payouts",
+		"/api/v1/fx/exchange":                             "fx/exchange",
+		"/api/v1/bills/categories/cat_1/items/item_1":     "bills/categories/{id}/items/{id}",
+		"/api/v1/resolve-account-number":                  "resolve-account-number",
+		"/api/v1/webhook/wh_1/test":                       "webhook/{id}/test",
+		"/api/v1/virtual-accounts/va_1/statements/2024-1": "{id}/{id}/{id}/{id}",
+		"/api/v1/": "",
+	}
+
+	for path, expected := range cases {
+		assert.Equal(t, routeTemplate("/api/v1/", path), expected, path)
+	}
+}
@@ -0,0 +1,172 @@
+package swervpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTimestamp is returned when a value is not a timestamp the API returns.
+var ErrInvalidTimestamp = errors.New("swervpay: invalid timestamp")
+
+// Timestamp is a point in time returned by the API. It accepts RFC 3339
+// strings, with or without fractional seconds, and seconds or milliseconds
+// since the epoch, given as a number or a string.
+//
+// A Timestamp remembers the value it was decoded from and encodes back to
+// exactly that value, so models round-trip through JSON unchanged. The zero
+// value is the zero time and encodes as "".
+type Timestamp struct {
+	t   time.Time
+	raw string // JSON value the timestamp was decoded from; empty when built from a time.
+}
+
+// NewTimestamp returns a Timestamp for t, encoded as an RFC 3339 string.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t: t}
+}
+
+// ParseTimestamp parses an RFC 3339 string or an epoch in seconds or
+// milliseconds. An empty string is the zero Timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	t, err := parseTimestamp(s)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	raw, _ := json.Marshal(s)
+	return Timestamp{t: t, raw: string(raw)}, nil
+}
+
+// MustParseTimestamp is like ParseTimestamp but panics on invalid input. It
+// is meant for constants in code and tests.
+func MustParseTimestamp(s string) Timestamp {
+	ts, err := ParseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// Time returns the timestamp as a time.Time.
+func (ts Timestamp) Time() time.Time {
+	return ts.t
+}
+
+// IsZero reports whether the timestamp is the zero time, e.g. because the
+// API returned an empty string.
+func (ts Timestamp) IsZero() bool {
+	return ts.t.IsZero()
+}
+
+// Unix returns the timestamp in seconds since the epoch.
+func (ts Timestamp) Unix() int64 {
+	if ts.t.IsZero() {
+		return 0
+	}
+	return ts.t.Unix()
+}
+
+// Equal reports whether ts and o are the same instant, however they were written.
+func (ts Timestamp) Equal(o Timestamp) bool {
+	return ts.t.Equal(o.t)
+}
+
+// String returns the value the timestamp was decoded from, such as
+// "2024-01-01T00:00:00Z" or "1704067200", or the RFC 3339 form of a
+// timestamp built from a time.
+func (ts Timestamp) String() string {
+	if ts.raw != "" {
+		var s string
+		if json.Unmarshal([]byte(ts.raw), &s) == nil {
+			return s
+		}
+		return ts.raw
+	}
+	if ts.t.IsZero() {
+		return ""
+	}
+	return ts.t.Format(time.RFC3339Nano)
+}
+
+// MarshalJSON encodes the timestamp as the value it was decoded from.
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.raw != "" {
+		return []byte(ts.raw), nil
+	}
+	return json.Marshal(ts.String())
+}
+
+// UnmarshalJSON decodes an RFC 3339 string or an epoch number or string.
+// null and "" decode to the zero Timestamp.
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*ts = Timestamp{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidTimestamp, data)
+		}
+	}
+
+	t, err := parseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*ts = Timestamp{t: t, raw: string(data)}
+	return nil
+}
+
+// MarshalText encodes the timestamp as String does.
+func (ts Timestamp) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+// UnmarshalText decodes an RFC 3339 string or an epoch.
+func (ts *Timestamp) UnmarshalText(text []byte) error {
+	v, err := ParseTimestamp(string(text))
+	if err != nil {
+		return err
+	}
+	*ts = v
+	return nil
+}
+
+// parseTimestamp parses the formats Timestamp accepts.
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return epochTime(n), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q is neither RFC 3339 nor an epoch", ErrInvalidTimestamp, s)
+}
+
+// epochTime converts seconds or milliseconds since the epoch to a time. Values
+// above 1e12, which would be after the year 33658 in seconds, are taken as
+// milliseconds, and values up to zero as unknown.
+func epochTime(n int64) time.Time {
+	switch {
+	case n <= 0:
+		return time.Time{}
+	case n > 1e12:
+		return time.UnixMilli(n)
+	default:
+		return time.Unix(n, 0)
+	}
+}
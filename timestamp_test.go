@@ -0,0 +1,105 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampFormats(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		json string
+		want time.Time
+	}{
+		{`"2024-01-02T03:04:05Z"`, want},
+		{`"2024-01-02T03:04:05.123456Z"`, want.Add(123456 * time.Microsecond)},
+		{`"2024-01-02T04:04:05+01:00"`, want},
+		{`1704164645`, want},
+		{`1704164645123`, want.Add(123 * time.Millisecond)},
+		{`"1704164645"`, want},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		var ts Timestamp
+		assert.NoError(t, json.Unmarshal([]byte(tt.json), &ts), tt.json)
+		assert.True(t, ts.Time().Equal(tt.want), tt.json)
+		assert.Equal(t, tt.want.IsZero(), ts.IsZero(), tt.json)
+	}
+
+	for _, in := range []string{`"yesterday"`, `"2024-01-02"`, `true`, `1.5`} {
+		var ts Timestamp
+		assert.ErrorIs(t, json.Unmarshal([]byte(in), &ts), ErrInvalidTimestamp, in)
+	}
+}
+
+func TestTimestampRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		`"2024-01-02T03:04:05Z"`,
+		`"2024-01-02T03:04:05.120000Z"`,
+		`"2024-01-02T04:04:05+01:00"`,
+		`1704164645`,
+		`"1704164645"`,
+		`""`,
+	} {
+		var ts Timestamp
+		assert.NoError(t, json.Unmarshal([]byte(in), &ts))
+
+		out, err := json.Marshal(ts)
+		assert.NoError(t, err)
+		assert.Equal(t, in, string(out))
+	}
+
+	out, err := json.Marshal(NewTimestamp(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.NoError(t, err)
+	assert.Equal(t, `"2024-01-02T03:04:05Z"`, string(out))
+
+	out, err = json.Marshal(Timestamp{})
+	assert.NoError(t, err)
+	assert.Equal(t, `""`, string(out))
+}
+
+func TestTimestampString(t *testing.T) {
+	assert.Equal(t, "2024-01-02T03:04:05.120000Z", MustParseTimestamp("2024-01-02T03:04:05.120000Z").String())
+	assert.Equal(t, "1704164645", MustParseTimestamp("1704164645").String())
+	assert.Equal(t, "", Timestamp{}.String())
+	assert.Equal(t, int64(1704164645), MustParseTimestamp("1704164645").Unix())
+	assert.True(t, MustParseTimestamp("1704164645").Equal(MustParseTimestamp("2024-01-02T03:04:05Z")))
+	assert.Equal(t, "created at 2024-01-02T03:04:05Z", fmt.Sprintf("created at %s", MustParseTimestamp("2024-01-02T03:04:05Z")))
+	assert.Panics(t, func() { MustParseTimestamp("soon") })
+}
+
+func TestModelTimestamps(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"id": "txn_001", "created_at": "2024-01-02T03:04:05.5Z", "updated_at": 1704164645}`
+	mux.HandleFunc("/transactions/txn_001", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, body)
+	})
+
+	transaction, err := client.Transaction.Get(context.Background(), "txn_001")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 5e8, time.UTC), transaction.CreatedAt.Time())
+	assert.Equal(t, int64(1704164645), transaction.UpdatedAt.Unix())
+
+	out, err := json.Marshal(transaction)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"created_at":"2024-01-02T03:04:05.5Z"`)
+	assert.Contains(t, string(out), `"updated_at":1704164645`)
+
+	var token TokenDetail
+	assert.NoError(t, json.Unmarshal([]byte(`{"type": "Bearer", "expires_at": 1704164645000, "issued_at": 1704161045}`), &token))
+	assert.Equal(t, time.UnixMilli(1704164645000), token.ExpiresAt.Time())
+	assert.Equal(t, int64(1704161045), token.IssuedAt.Unix())
+}
@@ -0,0 +1,161 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrAuthentication is returned when the client cannot obtain an access token
+// or the API keeps rejecting the token it obtained.
+var ErrAuthentication = errors.New("swervpay: authentication failed")
+
+// tokenRefreshSkew is how long before expiry a token is considered stale.
+const tokenRefreshSkew = 30 * time.Second
+
+// tokenRefreshFunc fetches a new access token together with its expiry.
+type tokenRefreshFunc func(ctx context.Context) (string, time.Time, error)
+
+// tokenFlight tracks a refresh in progress so concurrent callers can wait on it.
+type tokenFlight struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// tokenManager hands out access tokens, refreshing them shortly before they
+// expire. Only one refresh runs at a time; other callers wait for its result.
+// Refreshes first look for a token in the shared store, if there is one, and
+// publish newly issued tokens to it.
+type tokenManager struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time // Zero when the expiry is unknown.
+	rejected  string    // Last token rejected by the API, never taken from the store again.
+	flight    *tokenFlight
+	refresh   tokenRefreshFunc // Nil when the client has no credentials.
+	store     TokenStore
+	key       string // Key of the token in store.
+	now       func() time.Time
+}
+
+func newTokenManager(refresh tokenRefreshFunc, store TokenStore, key string) *tokenManager {
+	return &tokenManager{refresh: refresh, store: store, key: key, now: time.Now}
+}
+
+// seed sets a token obtained elsewhere, with an unknown expiry.
+func (m *tokenManager) seed(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.token = token
+	m.expiresAt = time.Time{}
+}
+
+// valid reports whether the current token can be used. The caller must hold mu.
+func (m *tokenManager) valid() bool {
+	return m.token != "" && m.fresh(m.expiresAt)
+}
+
+// fresh reports whether a token expiring at expiresAt is outside the refresh window.
+func (m *tokenManager) fresh(expiresAt time.Time) bool {
+	return expiresAt.IsZero() || m.now().Add(tokenRefreshSkew).Before(expiresAt)
+}
+
+// Token returns a usable access token, refreshing it first when needed.
+// An empty token is returned when there is neither a token nor a way to get one.
+func (m *tokenManager) Token(ctx context.Context) (string, error) {
+	for {
+		m.mu.Lock()
+
+		if m.valid() || m.refresh == nil {
+			token := m.token
+			m.mu.Unlock()
+			return token, nil
+		}
+
+		if f := m.flight; f != nil {
+			m.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-f.done:
+			}
+
+			// A refresh abandoned by its own caller says nothing about ours; try again.
+			if f.err != nil && isContextError(f.err) && ctx.Err() == nil {
+				continue
+			}
+			return f.token, f.err
+		}
+
+		f := &tokenFlight{done: make(chan struct{})}
+		m.flight = f
+		rejected := m.rejected
+		m.mu.Unlock()
+
+		token, expiresAt, err := m.fetch(ctx, rejected)
+
+		m.mu.Lock()
+		if err == nil {
+			m.token = token
+			m.expiresAt = expiresAt
+		}
+		f.token, f.err = token, err
+		m.flight = nil
+		close(f.done)
+		m.mu.Unlock()
+
+		return token, err
+	}
+}
+
+// fetch returns a token from the store when it holds a usable one, and
+// otherwise authenticates and saves the new token to the store. Store failures
+// are not fatal: the store is only an optimisation over authenticating.
+func (m *tokenManager) fetch(ctx context.Context, rejected string) (string, time.Time, error) {
+	if m.store != nil {
+		t, err := m.store.Get(ctx, m.key)
+		if err == nil && t != nil && t.AccessToken != "" && t.AccessToken != rejected && m.fresh(t.ExpiresAt) {
+			return t.AccessToken, t.ExpiresAt, nil
+		}
+	}
+
+	token, expiresAt, err := m.refresh(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if m.store != nil {
+		_ = m.store.Set(ctx, m.key, &Token{AccessToken: token, ExpiresAt: expiresAt})
+	}
+
+	return token, expiresAt, nil
+}
+
+// invalidate discards token so that the next call to Token refreshes it.
+// A token that has already been replaced by a newer one is left alone.
+func (m *tokenManager) invalidate(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if token != "" {
+		m.rejected = token
+	}
+
+	if m.token == token {
+		m.token = ""
+		m.expiresAt = time.Time{}
+	}
+}
+
+// canRefresh reports whether the manager is able to fetch new tokens.
+func (m *tokenManager) canRefresh() bool {
+	return m.refresh != nil
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,183 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Token is an access token together with its expiry.
+type Token struct {
+	AccessToken string    `json:"access_token"` // Bearer token used to authorize requests.
+	ExpiresAt   time.Time `json:"expires_at"`   // Expiry of the token; zero when unknown.
+}
+
+// TokenStore persists access tokens so that several clients, possibly in
+// different processes, can share the token of a business instead of each
+// authenticating on their own.
+//
+// Keys identify a business on a given Swervpay environment. Get returns a nil
+// token and a nil error when nothing is stored for the key. Implementations
+// must be safe for concurrent use.
+type TokenStore interface {
+	Get(ctx context.Context, key string) (*Token, error)
+	Set(ctx context.Context, key string, token *Token) error
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory. A single
+// store may be shared by every client of a process.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]Token
+}
+
+// Verify that MemoryTokenStore implements TokenStore.
+var _ TokenStore = &MemoryTokenStore{}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]Token{}}
+}
+
+// Get returns the token stored for key, if any.
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+// Set stores token under key.
+func (s *MemoryTokenStore) Set(_ context.Context, key string, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil {
+		s.tokens = map[string]Token{}
+	}
+	s.tokens[key] = *token
+	return nil
+}
+
+// FileTokenStore is a TokenStore backed by a JSON file, so that processes on
+// the same host share tokens. Access is serialised with a lock on a sibling
+// ".lock" file, and updates replace the file atomically.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex // Serialises access within the process; the file lock covers other processes.
+}
+
+// Verify that FileTokenStore implements TokenStore.
+var _ TokenStore = &FileTokenStore{}
+
+// NewFileTokenStore creates a FileTokenStore that keeps its tokens in path.
+// The file and its directory are created on the first Set.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Get returns the token stored for key, if any.
+func (s *FileTokenStore) Get(ctx context.Context, key string) (*Token, error) {
+	var token *Token
+
+	err := s.withLock(ctx, func() error {
+		tokens, err := s.read()
+		if err != nil {
+			return err
+		}
+		if t, ok := tokens[key]; ok {
+			token = &t
+		}
+		return nil
+	})
+
+	return token, err
+}
+
+// Set stores token under key, keeping the tokens of other keys.
+func (s *FileTokenStore) Set(ctx context.Context, key string, token *Token) error {
+	return s.withLock(ctx, func() error {
+		tokens, err := s.read()
+		if err != nil {
+			return err
+		}
+		tokens[key] = *token
+		return s.write(tokens)
+	})
+}
+
+// withLock runs fn while holding both the in-process and the file lock.
+func (s *FileTokenStore) withLock(ctx context.Context, fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+
+	unlock, err := lockFile(ctx, s.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return fn()
+}
+
+// read loads the stored tokens. A missing file is treated as an empty store.
+func (s *FileTokenStore) read() (map[string]Token, error) {
+	tokens := map[string]Token{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return tokens, nil
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// write atomically replaces the stored tokens.
+func (s *FileTokenStore) write(tokens map[string]Token) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,146 @@
+package swervpay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	s := NewMemoryTokenStore()
+	ctx := context.Background()
+
+	token, err := s.Get(ctx, "biz_001")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	expiresAt := time.Now().Add(time.Hour)
+	assert.NoError(t, s.Set(ctx, "biz_001", &Token{AccessToken: "token_1", ExpiresAt: expiresAt}))
+
+	token, err = s.Get(ctx, "biz_001")
+	assert.NoError(t, err)
+	assert.Equal(t, token.AccessToken, "token_1")
+	assert.True(t, token.ExpiresAt.Equal(expiresAt))
+}
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swervpay", "tokens.json")
+	ctx := context.Background()
+
+	a := NewFileTokenStore(path)
+	b := NewFileTokenStore(path)
+
+	token, err := a.Get(ctx, "biz_001")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	assert.NoError(t, a.Set(ctx, "biz_001", &Token{AccessToken: "token_1", ExpiresAt: expiresAt}))
+	assert.NoError(t, b.Set(ctx, "biz_002", &Token{AccessToken: "token_2"}))
+
+	token, err = b.Get(ctx, "biz_001")
+	assert.NoError(t, err)
+	if assert.NotNil(t, token) {
+		assert.Equal(t, token.AccessToken, "token_1")
+		assert.True(t, token.ExpiresAt.Equal(expiresAt))
+	}
+
+	token, err = a.Get(ctx, "biz_002")
+	assert.NoError(t, err)
+	if assert.NotNil(t, token) {
+		assert.Equal(t, token.AccessToken, "token_2")
+	}
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, info.Mode().Perm(), os.FileMode(0o600))
+	}
+}
+
+func TestFileTokenStoreConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Separate stores so only the file lock serialises the writers.
+			s := NewFileTokenStore(path)
+			assert.NoError(t, s.Set(ctx, fmt.Sprintf("biz_%d", i), &Token{AccessToken: fmt.Sprintf("token_%d", i)}))
+		}(i)
+	}
+	wg.Wait()
+
+	s := NewFileTokenStore(path)
+	for i := 0; i < 20; i++ {
+		token, err := s.Get(ctx, fmt.Sprintf("biz_%d", i))
+		assert.NoError(t, err)
+		if assert.NotNil(t, token) {
+			assert.Equal(t, token.AccessToken, fmt.Sprintf("token_%d", i))
+		}
+	}
+}
+
+func TestClientsShareTokenStore(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != s.currentToken() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	})
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	for i := 0; i < 3; i++ {
+		c := s.client("sk_001")
+		c.tokens.store = store
+
+		_, err := c.Business.Get(context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(1))
+}
+
+func TestTokenStoreRejectedTokenNotReused(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != s.currentToken() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	})
+
+	c := s.client("sk_001")
+	store := NewMemoryTokenStore()
+	c.tokens.store = store
+
+	// A stale token left in the store by another process.
+	assert.NoError(t, store.Set(context.Background(), c.tokens.key, &Token{AccessToken: "revoked"}))
+
+	_, err := c.Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(1))
+
+	token, err := store.Get(context.Background(), c.tokens.key)
+	assert.NoError(t, err)
+	assert.Equal(t, token.AccessToken, "token_1")
+}
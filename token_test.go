@@ -0,0 +1,240 @@
+package swervpay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// authServer is a test server that issues numbered tokens from /auth and
+// only accepts the latest one on every other route.
+type authServer struct {
+	*httptest.Server
+	mux       *http.ServeMux
+	authCalls int32
+	expiresIn time.Duration
+	authDelay time.Duration
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	s := &authServer{mux: http.NewServeMux(), expiresIn: time.Hour}
+	s.Server = httptest.NewServer(s.mux)
+
+	s.mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "biz_001" || secret != "sk_001" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Invalid credentials"}`))
+			return
+		}
+
+		time.Sleep(s.authDelay)
+		n := atomic.AddInt32(&s.authCalls, 1)
+
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(&AuthResponse{
+			AccessToken: fmt.Sprintf("token_%d", n),
+			Token:       TokenDetail{Type: "Bearer", ExpiresAt: NewTimestamp(time.Now().Add(s.expiresIn))},
+		})
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return s
+}
+
+func (s *authServer) currentToken() string {
+	return fmt.Sprintf("Bearer token_%d", atomic.LoadInt32(&s.authCalls))
+}
+
+func (s *authServer) client(secret string) *SwervpayClient {
+	c := NewSwervpayClient(&SwervpayClientOption{
+		BusinessID: "biz_001",
+		SecretKey:  secret,
+		BaseURL:    s.URL + "/",
+		Retry:      &RetryPolicy{MaxAttempts: 1},
+	})
+	u, _ := url.Parse(s.URL + "/")
+	c.BaseURL = u
+	return c
+}
+
+func TestTokenFetchedBeforeFirstRequest(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+
+	var unauthorized int32
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != s.currentToken() {
+			atomic.AddInt32(&unauthorized, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	})
+
+	resp, err := s.client("sk_001").Business.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, "biz_001")
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(1))
+	assert.Equal(t, atomic.LoadInt32(&unauthorized), int32(0))
+}
+
+func TestTokenConcurrentRequestsShareOneRefresh(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+	s.authDelay = 20 * time.Millisecond
+
+	s.mux.HandleFunc("/cards/card_001", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != s.currentToken() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"card_001"}`))
+	})
+
+	c := s.client("sk_001")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Card.Get(context.Background(), "card_001")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(1))
+}
+
+func TestTokenRefreshedBeforeExpiry(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+	s.expiresIn = tokenRefreshSkew / 2
+
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"biz_001"}`))
+	})
+
+	c := s.client("sk_001")
+	for i := 0; i < 3; i++ {
+		_, err := c.Business.Get(context.Background())
+		assert.NoError(t, err)
+	}
+
+	// Every token is already inside the refresh window, so each call refreshes.
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(3))
+}
+
+func TestTokenReauthenticatesOnce(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+
+	var calls int32
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Token revoked"}`))
+	})
+
+	_, err := s.client("sk_001").Business.Get(context.Background())
+	assert.ErrorIs(t, err, ErrAuthentication)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+	assert.Equal(t, atomic.LoadInt32(&s.authCalls), int32(2))
+}
+
+func TestTokenInvalidCredentials(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+
+	var calls int32
+	s.mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	})
+
+	c := s.client("wrong")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Business.Get(context.Background())
+			assert.ErrorIs(t, err, ErrAuthentication)
+			assert.EqualError(t, err, "swervpay: authentication failed: [ERROR]: Invalid credentials")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(0))
+}
+
+func TestTokenUsesCallerContext(t *testing.T) {
+	s := newAuthServer(t)
+	defer s.Close()
+	s.authDelay = time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := s.client("sk_001").Business.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), s.authDelay)
+}
+
+func TestTokenManagerWaiterRetriesAbandonedRefresh(t *testing.T) {
+	started := make(chan struct{})
+	var calls int32
+
+	m := newTokenManager(func(ctx context.Context) (string, time.Time, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return "", time.Time{}, ctx.Err()
+		}
+		return "token", time.Now().Add(time.Hour), nil
+	}, nil, "")
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _ = m.Token(leaderCtx)
+	}()
+	<-started
+
+	done := make(chan string)
+	go func() {
+		token, err := m.Token(context.Background())
+		assert.NoError(t, err)
+		done <- token
+	}()
+
+	cancel()
+	assert.Equal(t, <-done, "token")
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestTokenExpiry(t *testing.T) {
+	assert.True(t, epochTime(0).IsZero())
+	assert.Equal(t, epochTime(1700000000), time.Unix(1700000000, 0))
+	assert.Equal(t, epochTime(1700000000123), time.UnixMilli(1700000000123))
+}
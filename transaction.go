@@ -2,40 +2,81 @@ package swervpay
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
 // Transaction represents a transaction with all its details.
 type Transaction struct {
-	AccountName   string  `json:"account_name"`             // The name of the account
-	AccountNumber string  `json:"account_number"`           // The number of the account
-	Amount        float64 `json:"amount"`                   // The amount of the transaction
-	BankCode      string  `json:"bank_code"`                // The code of the bank
-	BankName      string  `json:"bank_name"`                // The name of the bank
-	Category      string  `json:"category"`                 // The category of the transaction
-	Charges       float64 `json:"charges"`                  // The charges of the transaction
-	CreatedAt     string  `json:"created_at"`               // The creation date of the transaction
-	Detail        string  `json:"detail"`                   // The detail of the transaction
-	FiatRate      float64 `json:"fiat_rate"`                // The fiat rate of the transaction
-	ID            string  `json:"id"`                       // The ID of the transaction
-	Imad          string  `json:"imad,omitempty"`           // IMAD reference
-	PaymentMethod string  `json:"payment_method,omitempty"` // The payment method used
-	Reference     string  `json:"reference"`                // The reference of the transaction
-	Report        bool    `json:"report"`                   // The report status of the transaction
-	ReportMessage string  `json:"report_message"`           // The report message of the transaction
-	SessionID     string  `json:"session_id"`               // The session ID of the transaction
-	Status        string  `json:"status"`                   // The status of the transaction
-	TraceNumber   string  `json:"trace_number,omitempty"`   // The trace number of the transaction
-	Type          string  `json:"type"`                     // The type of the transaction
-	UpdatedAt     string  `json:"updated_at"`               // The update date of the transaction
-	Collection    Wallet  `json:"collection,omitempty"`     // The collection wallet details
-	Wallet        Wallet  `json:"wallet,omitempty"`         // The wallet details
+	AccountName   string              `json:"account_name"`             // The name of the account
+	AccountNumber string              `json:"account_number"`           // The number of the account
+	Amount        float64             `json:"amount"`                   // The amount of the transaction
+	BankCode      string              `json:"bank_code"`                // The code of the bank
+	BankName      string              `json:"bank_name"`                // The name of the bank
+	Category      TransactionCategory `json:"category"`                 // The category of the transaction
+	Charges       float64             `json:"charges"`                  // The charges of the transaction
+	CreatedAt     Timestamp           `json:"created_at"`               // The creation date of the transaction
+	Detail        string              `json:"detail"`                   // The detail of the transaction
+	FiatRate      float64             `json:"fiat_rate"`                // The fiat rate of the transaction
+	ID            string              `json:"id"`                       // The ID of the transaction
+	Imad          string              `json:"imad,omitempty"`           // IMAD reference
+	PaymentMethod string              `json:"payment_method,omitempty"` // The payment method used
+	Reference     string              `json:"reference"`                // The reference of the transaction
+	Report        bool                `json:"report"`                   // The report status of the transaction
+	ReportMessage string              `json:"report_message"`           // The report message of the transaction
+	SessionID     string              `json:"session_id"`               // The session ID of the transaction
+	Status        TransactionStatus   `json:"status"`                   // The status of the transaction
+	TraceNumber   string              `json:"trace_number,omitempty"`   // The trace number of the transaction
+	Type          TransactionType     `json:"type"`                     // The type of the transaction
+	UpdatedAt     Timestamp           `json:"updated_at"`               // The update date of the transaction
+	Collection    Wallet              `json:"collection,omitempty"`     // The collection wallet details
+	Wallet        Wallet              `json:"wallet,omitempty"`         // The wallet details
+
+	IdempotencyKey string `json:"-"` // The idempotency key the request was sent with, for calls that use one
+
+	Extra map[string]json.RawMessage `json:"-"` // Fields returned by the API that the model does not have yet
+}
+
+// UnmarshalJSON decodes a transaction, keeping unknown fields in Extra.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	if err := json.Unmarshal(data, (*transaction)(t)); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, (*transaction)(t))
+	if err != nil {
+		return err
+	}
+	t.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes a transaction, including the fields kept in Extra.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	type transaction Transaction
+	return marshalWithExtra(transaction(t), t.Extra)
+}
+
+func (t *Transaction) extraFields() map[string]json.RawMessage {
+	return t.Extra
+}
+
+// AmountDecimal returns Amount as an exact decimal, for summing without drift.
+func (t Transaction) AmountDecimal() Amount {
+	return AmountFromFloat(t.Amount)
+}
+
+// ChargesDecimal returns Charges as an exact decimal.
+func (t Transaction) ChargesDecimal() Amount {
+	return AmountFromFloat(t.Charges)
 }
 
 // TransactionInt is an interface that defines the methods for transactions.
 type TransactionInt interface {
-	Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Transaction, error) // Gets a list of transactions
-	Get(ctx context.Context, id string) (*Transaction, error)                   // Gets a single transaction
+	Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Transaction, error) // Gets a list of transactions
+	Get(ctx context.Context, id string, opts ...CallOption) (*Transaction, error)                   // Gets a single transaction
 }
 
 // TransactionIntImpl is the implementation of the TransactionInt interface.
@@ -48,10 +89,10 @@ var _ TransactionInt = &TransactionIntImpl{}
 
 // Gets retrieves a list of transactions.
 // https://docs.swervpay.co/api-reference/transactions/get-all-transactions
-func (t TransactionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Transaction, error) {
+func (t TransactionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Transaction, error) {
 	path := GenerateURLPath("transactions", query)
 
-	req, err := t.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := t.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +110,8 @@ func (t TransactionIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery)
 
 // Get retrieves a single transaction.
 // https://docs.swervpay.co/api-reference/transactions/get
-func (t TransactionIntImpl) Get(ctx context.Context, id string) (*Transaction, error) {
-	req, err := t.client.NewRequest(ctx, http.MethodGet, "transactions/"+id, nil)
+func (t TransactionIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Transaction, error) {
+	req, err := t.client.NewRequest(ctx, http.MethodGet, "transactions/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func TestTransactionGets(t *testing.T) {
 	assert.Len(t, resp, 2)
 	assert.Equal(t, resp[0].ID, "txn_001")
 	assert.Equal(t, resp[0].Amount, 1000.0)
-	assert.Equal(t, resp[0].Status, "success")
+	assert.Equal(t, resp[0].Status, TransactionStatus("success"))
 	assert.Equal(t, resp[1].ID, "txn_002")
 	assert.Equal(t, resp[1].Amount, 500.0)
 }
@@ -93,8 +93,8 @@ func TestTransactionGet(t *testing.T) {
 			AccountNumber: "1234567890",
 			BankCode:      "058",
 			BankName:      "GTBank",
-			CreatedAt:     "2024-01-01T00:00:00Z",
-			UpdatedAt:     "2024-01-01T00:00:00Z",
+			CreatedAt:     MustParseTimestamp("2024-01-01T00:00:00Z"),
+			UpdatedAt:     MustParseTimestamp("2024-01-01T00:00:00Z"),
 		}
 		err := json.NewEncoder(w).Encode(&ret)
 		if err != nil {
@@ -107,7 +107,7 @@ func TestTransactionGet(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.ID, transactionId)
 	assert.Equal(t, resp.Amount, 1000.0)
-	assert.Equal(t, resp.Status, "success")
-	assert.Equal(t, resp.Type, "credit")
+	assert.Equal(t, resp.Status, TransactionStatus("success"))
+	assert.Equal(t, resp.Type, TransactionType("credit"))
 	assert.Equal(t, resp.Charges, 10.0)
 }
@@ -0,0 +1,188 @@
+package swervpay
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+)
+
+// ValidationError is returned, before any request is sent, when a request
+// body has invalid fields. It matches ErrValidation through errors.Is, like
+// the API's own validation errors.
+type ValidationError struct {
+	Body   string        // Type of the request body, e.g. "CreatePayoutBody".
+	Fields []ErrorDetail // Invalid fields, named as in JSON, e.g. "document.document_number".
+
+	errs []error // Sentinel errors of the fields, e.g. ErrUnsupportedCurrency.
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+" "+f.Message)
+	}
+	return fmt.Sprintf("swervpay: invalid %s: %s", e.Body, strings.Join(parts, "; "))
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the sentinel errors of the invalid fields.
+func (e *ValidationError) Unwrap() []error {
+	return e.errs
+}
+
+// Field returns the message of the named field, if it is invalid.
+func (e *ValidationError) Field(name string) (string, bool) {
+	for _, f := range e.Fields {
+		if f.Field == name {
+			return f.Message, true
+		}
+	}
+	return "", false
+}
+
+// WithValidation turns the validation of request bodies before they are sent
+// on or off. It is on by default.
+func WithValidation(enabled bool) Option {
+	return func(o *clientOptions) error {
+		o.skipValidation = !enabled
+		return nil
+	}
+}
+
+// WithCallValidation turns the validation of the request body on or off for
+// the call, overriding WithValidation, e.g. to send a body the SDK does not
+// know to be valid yet.
+func WithCallValidation(enabled bool) CallOption {
+	return func(s *callSettings) {
+		s.validate = &enabled
+	}
+}
+
+// validator is implemented by request bodies that can be checked before they are sent.
+type validator interface {
+	Validate() error
+}
+
+// validateBody validates params when it is a request body with a Validate method.
+func validateBody(params interface{}) error {
+	v, ok := params.(validator)
+	if !ok {
+		return nil
+	}
+	if rv := reflect.ValueOf(params); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	return v.Validate()
+}
+
+// validation collects the invalid fields of a request body.
+type validation struct {
+	err ValidationError
+}
+
+func newValidation(body string) *validation {
+	return &validation{err: ValidationError{Body: body}}
+}
+
+// fail records an invalid field.
+func (v *validation) fail(field, message string, errs ...error) {
+	v.err.Fields = append(v.err.Fields, ErrorDetail{Field: field, Message: message})
+	v.err.errs = append(v.err.errs, errs...)
+}
+
+// required checks that a string field is not blank.
+func (v *validation) required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.fail(field, "is required")
+		return false
+	}
+	return true
+}
+
+// positive checks that an amount is greater than zero.
+func (v *validation) positive(field string, amount float64) {
+	if !(amount > 0) {
+		v.fail(field, "must be greater than zero")
+	}
+}
+
+// nonNegative checks that an amount is zero or more.
+func (v *validation) nonNegative(field string, amount float64) {
+	if !(amount >= 0) {
+		v.fail(field, "must not be negative")
+	}
+}
+
+// currency checks that a currency is supported, and set when required.
+func (v *validation) currency(field string, c Currency, required bool) {
+	if c == "" {
+		if required {
+			v.fail(field, "is required")
+		}
+		return
+	}
+	if !c.IsSupported() {
+		v.fail(field, fmt.Sprintf("%q is not a supported currency", string(c)), ErrUnsupportedCurrency)
+	}
+}
+
+// email checks that a non-empty field is a plain email address.
+func (v *validation) email(field, value string) {
+	if value == "" {
+		return
+	}
+	if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+		v.fail(field, "must be a valid email address")
+	}
+}
+
+// format records a field whose non-empty value fails check.
+func (v *validation) format(field, value string, check func(string) string) {
+	if value == "" {
+		return
+	}
+	if problem := check(value); problem != "" {
+		v.fail(field, problem)
+	}
+}
+
+// nuban checks that the Nigerian account number of a bank in the embedded
+// table is a valid NUBAN of the bank. The table is not exhaustive, so accounts
+// of other banks are left to the API. prefix names the object holding the
+// fields, e.g. "sender.".
+func (v *validation) nuban(prefix, accountNumber, bankCode string) {
+	if _, known := banks[bankCode]; !known {
+		return
+	}
+	if field, message, err := nubanIssue(accountNumber, bankCode); err != nil {
+		v.fail(prefix+field, message, err)
+	}
+}
+
+// nested records the invalid fields of a nested object under prefix.
+func (v *validation) nested(prefix string, err error) {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return
+	}
+	for _, f := range verr.Fields {
+		v.fail(prefix+f.Field, f.Message)
+	}
+	v.err.errs = append(v.err.errs, verr.errs...)
+}
+
+// result returns the collected error, or nil when every field is valid.
+func (v *validation) result() error {
+	if len(v.err.Fields) == 0 {
+		return nil
+	}
+	err := v.err
+	return &err
+}
@@ -0,0 +1,130 @@
+package swervpay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	err := CreatePayoutBody{Currency: "NGm", Amount: -5}.Validate()
+
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "CreatePayoutBody", verr.Body)
+		assert.Equal(t, []ErrorDetail{
+			{Field: "account_number", Message: "is required"},
+			{Field: "bank_code", Message: "is required"},
+			{Field: "currency", Message: `"NGm" is not a supported currency`},
+			{Field: "amount", Message: "must be greater than zero"},
+		}, verr.Fields)
+
+		msg, ok := verr.Field("bank_code")
+		assert.True(t, ok)
+		assert.Equal(t, "is required", msg)
+		_, ok = verr.Field("narration")
+		assert.False(t, ok)
+	}
+
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+	assert.EqualError(t, err, `swervpay: invalid CreatePayoutBody: account_number is required; bank_code is required; currency "NGm" is not a supported currency; amount must be greater than zero`)
+
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
+
+func TestRequestBodyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   validator
+		fields []string
+	}{
+		{"valid payout", CreatePayoutBody{AccountNumber: "0123456785", BankCode: "058", Currency: NGN, Amount: 100}, nil},
+		{"negative fund", FundOrWithdrawCardBody{Amount: -1}, []string{"amount"}},
+		{"zero withdraw", FundOrWithdrawCardBody{}, []string{"amount"}},
+		{"valid fund", FundOrWithdrawCardBody{Amount: 10}, nil},
+		{"same fx currencies", FxBody{Amount: 1, From: USD, To: USD}, []string{"to"}},
+		{"missing fx currencies", FxBody{Amount: 1}, []string{"from", "to"}},
+		{"business card", CreateCardBody{Type: CardTypeBusiness, BusinessEmail: "ops@"}, []string{"rc_number", "director_bvn", "business_email"}},
+		{"valid business card", CreateCardBody{Type: "business", RCNumber: "RC123", DirectorBvn: "22212345678", BusinessEmail: "ops@example.com"}, nil},
+		{"customer card", CreateCardBody{Type: CardTypeDefault, Amount: -1}, []string{"customer_id", "amount"}},
+		{"card with local phone", CreateCardBody{CustomerId: "cus_001", PhoneNumber: "08012345678"}, nil},
+		{"card document", CreateCardBody{CustomerId: "cus_001", Document: CreateCardDocumentInput{DocumentType: "NIN"}}, []string{"document.document_number"}},
+		{"customer email", CreateCustomerBody{Firstname: "Ada", Lastname: "Obi", Email: "ada@", Country: "NG"}, []string{"email"}},
+		{"customer display name", CreateCustomerBody{Firstname: "Ada", Lastname: "Obi", Email: "Ada <ada@example.com>", Country: "NG"}, []string{"email"}},
+		{"empty customer", CreateCustomerBody{}, []string{"firstname", "lastname", "email", "country"}},
+		{"customer update", UpdateustomerBody{Email: "not-an-email"}, []string{"email"}},
+		{"phone only update", UpdateustomerBody{PhoneNumber: "+2348012345678"}, nil},
+		{"local phone update", UpdateustomerBody{PhoneNumber: "08012345678"}, nil},
+		{"tier 1 kyc", CustomerKycBody{Tier: "1"}, []string{"information.bvn", "information.phone_number", "information.address", "information.city", "information.state", "information.country"}},
+		{"tier 2 kyc", CustomerKycBody{Tier: "2"}, []string{"document.document_type", "document.document_number", "document.document"}},
+		{"unknown tier", CustomerKycBody{Tier: "3"}, []string{"tier"}},
+		{"collection", CreateCollectionBody{Amount: -1}, []string{"customer_id", "currency", "amount"}},
+		{"resolve account", ResolveAccountNumberBody{}, []string{"account_number", "bank_code"}},
+		{"credit wallet", CreditWalletBody{}, []string{"amount"}},
+		{"create bill", CreateBillBody{Amount: 100, BillerID: "biller_001"}, []string{"category", "customer_id", "item_id"}},
+		{"validate bill", ValidateBillBody{}, []string{"biller_id", "category", "customer_id", "item_id"}},
+	}
+
+	for _, tt := range tests {
+		err := tt.body.Validate()
+		if tt.fields == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+
+		var verr *ValidationError
+		if assert.ErrorAs(t, err, &verr, tt.name) {
+			var fields []string
+			for _, f := range verr.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.fields, fields, tt.name)
+		}
+	}
+}
+
+func TestValidationBeforeRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/cards/card_001/fund", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "ok"}`))
+	})
+
+	ctx := context.Background()
+
+	_, err := client.Card.Fund(ctx, "card_001", &FundOrWithdrawCardBody{Amount: -10})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, 0, calls)
+
+	_, err = client.Card.Fund(ctx, "card_001", &FundOrWithdrawCardBody{Amount: -10}, WithCallValidation(false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	_, err = Do[CardActionResponse](ctx, client, http.MethodPost, "cards/card_001/fund", FundOrWithdrawCardBody{})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, 1, calls)
+
+	var body *FundOrWithdrawCardBody
+	_, err = client.NewRequest(ctx, http.MethodPost, "cards/card_001/fund", body)
+	assert.NoError(t, err)
+}
+
+func TestWithValidationDisabled(t *testing.T) {
+	c, err := New(WithCredentials("biz_001", "sk_test_001"), WithValidation(false))
+	assert.NoError(t, err)
+
+	_, err = c.NewRequest(context.Background(), http.MethodPost, "payouts", &CreatePayoutBody{})
+	assert.NoError(t, err)
+
+	_, err = c.NewRequest(context.Background(), http.MethodPost, "payouts", &CreatePayoutBody{}, WithCallValidation(true))
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -2,27 +2,56 @@ package swervpay
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
 // Wallet represents a user's wallet in the system.
 type Wallet struct {
-	AccountName    string  `json:"account_name"`    // The name of the account.
-	AccountNumber  string  `json:"account_number"`  // The number of the account.
-	AccountType    string  `json:"account_type"`    // The type of the account.
-	Balance        float64 `json:"balance"`         // The current balance of the wallet.
-	BankAddress    string  `json:"bank_address"`    // The address of the bank.
-	BankCode       string  `json:"bank_code"`       // The code of the bank.
-	BankName       string  `json:"bank_name"`       // The name of the bank.
-	CreatedAt      string  `json:"created_at"`      // The creation date of the wallet.
-	ID             string  `json:"id"`              // The unique identifier of the wallet.
-	IsBlocked      bool    `json:"is_blocked"`      // Indicates if the wallet is blocked.
-	Label          string  `json:"label"`           // The label of the wallet.
-	PendingBalance float64 `json:"pending_balance"` // The pending balance of the wallet.
-	Reference      string  `json:"reference"`       // The reference of the wallet.
-	RoutingNumber  string  `json:"routing_number"`  // The routing number of the bank.
-	TotalReceived  float64 `json:"total_received"`  // The total amount received in the wallet.
-	UpdatedAt      string  `json:"updated_at"`      // The last update date of the wallet.
+	AccountName    string    `json:"account_name"`    // The name of the account.
+	AccountNumber  string    `json:"account_number"`  // The number of the account.
+	AccountType    string    `json:"account_type"`    // The type of the account.
+	Balance        float64   `json:"balance"`         // The current balance of the wallet.
+	BankAddress    string    `json:"bank_address"`    // The address of the bank.
+	BankCode       string    `json:"bank_code"`       // The code of the bank.
+	BankName       string    `json:"bank_name"`       // The name of the bank.
+	CreatedAt      Timestamp `json:"created_at"`      // The creation date of the wallet.
+	ID             string    `json:"id"`              // The unique identifier of the wallet.
+	IsBlocked      bool      `json:"is_blocked"`      // Indicates if the wallet is blocked.
+	Label          string    `json:"label"`           // The label of the wallet.
+	PendingBalance float64   `json:"pending_balance"` // The pending balance of the wallet.
+	Reference      string    `json:"reference"`       // The reference of the wallet.
+	RoutingNumber  string    `json:"routing_number"`  // The routing number of the bank.
+	TotalReceived  float64   `json:"total_received"`  // The total amount received in the wallet.
+	UpdatedAt      Timestamp `json:"updated_at"`      // The last update date of the wallet.
+
+	Extra map[string]json.RawMessage `json:"-"` // Fields returned by the API that the model does not have yet
+}
+
+// UnmarshalJSON decodes a wallet, keeping unknown fields in Extra.
+func (w *Wallet) UnmarshalJSON(data []byte) error {
+	type wallet Wallet
+	if err := json.Unmarshal(data, (*wallet)(w)); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, (*wallet)(w))
+	if err != nil {
+		return err
+	}
+	w.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes a wallet, including the fields kept in Extra.
+func (w Wallet) MarshalJSON() ([]byte, error) {
+	type wallet Wallet
+	return marshalWithExtra(wallet(w), w.Extra)
+}
+
+func (w *Wallet) extraFields() map[string]json.RawMessage {
+	return w.Extra
 }
 
 // CreditWalletBody represents the body of a credit wallet request.
@@ -31,6 +60,14 @@ type CreditWalletBody struct {
 	Sender CreditWalletSenderInput `json:"sender"` // Sender information.
 }
 
+// Validate checks the credit before it is sent.
+func (b CreditWalletBody) Validate() error {
+	v := newValidation("CreditWalletBody")
+	v.positive("amount", b.Amount)
+	v.nested("sender.", b.Sender.Validate())
+	return v.result()
+}
+
 // CreditWalletSenderInput represents the sender information for crediting a wallet.
 type CreditWalletSenderInput struct {
 	AccountName   string `json:"account_name"`   // Account name of the sender.
@@ -41,18 +78,33 @@ type CreditWalletSenderInput struct {
 	Reference     string `json:"reference"`      // Reference for the credit transaction.
 }
 
+// Validate checks the sender's account, when given.
+func (s CreditWalletSenderInput) Validate() error {
+	v := newValidation("CreditWalletSenderInput")
+	if s.AccountNumber != "" || s.BankCode != "" {
+		hasAccount := v.required("account_number", s.AccountNumber)
+		hasBank := v.required("bank_code", s.BankCode)
+		if hasAccount && hasBank {
+			v.nuban("", s.AccountNumber, s.BankCode)
+		}
+	}
+	return v.result()
+}
+
 // CreditWalletResponse represents the response from crediting a wallet.
 type CreditWalletResponse struct {
 	ID        string `json:"id"`        // Transaction ID.
 	Message   string `json:"message"`   // Response message.
 	Reference string `json:"reference"` // Transaction reference.
+
+	IdempotencyKey string `json:"-"` // Idempotency key the credit was sent with.
 }
 
 // WalletInt is an interface that defines the methods for managing wallets.
 type WalletInt interface {
-	Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Wallet, error)                        // Gets a list of wallets.
-	Get(ctx context.Context, id string) (*Wallet, error)                                          // Gets a specific wallet by its ID.
-	Credit(ctx context.Context, id string, body *CreditWalletBody) (*CreditWalletResponse, error) // Credits a wallet.
+	Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Wallet, error)                        // Gets a list of wallets.
+	Get(ctx context.Context, id string, opts ...CallOption) (*Wallet, error)                                          // Gets a specific wallet by its ID.
+	Credit(ctx context.Context, id string, body *CreditWalletBody, opts ...CallOption) (*CreditWalletResponse, error) // Credits a wallet.
 }
 
 // WalletIntImpl is an implementation of the WalletInt interface.
@@ -65,12 +117,12 @@ var _ WalletInt = &WalletIntImpl{}
 
 // Gets retrieves a list of wallets.
 // https://docs.swervpay.co/api-reference/wallets/get-all-wallets
-func (w WalletIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*Wallet, error) {
+func (w WalletIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery, opts ...CallOption) ([]*Wallet, error) {
 
 	path := GenerateURLPath("wallets", query)
 
 	// Prepare the request.
-	req, err := w.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := w.client.NewRequest(ctx, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,9 +142,9 @@ func (w WalletIntImpl) Gets(ctx context.Context, query *PageAndLimitQuery) ([]*W
 
 // Get retrieves a specific wallet by its ID.
 // https://docs.swervpay.co/api-reference/wallets/get
-func (w WalletIntImpl) Get(ctx context.Context, id string) (*Wallet, error) {
+func (w WalletIntImpl) Get(ctx context.Context, id string, opts ...CallOption) (*Wallet, error) {
 	// Prepare the request.
-	req, err := w.client.NewRequest(ctx, http.MethodGet, "wallets/"+id, nil)
+	req, err := w.client.NewRequest(ctx, http.MethodGet, "wallets/"+id, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,10 +162,17 @@ func (w WalletIntImpl) Get(ctx context.Context, id string) (*Wallet, error) {
 	return response, nil
 }
 
-// Credit credits a wallet.
+// Credit credits a wallet. It is a sandbox-only helper and returns ErrSandboxOnly on live.
+// An idempotency key is generated unless one is passed with WithIdempotencyKey.
+// The call is retried only with a key passed with WithIdempotencyKey or when
+// marked with WithRetryableCall.
 // https://docs.swervpay.co/api-reference/wallets/credit
-func (w WalletIntImpl) Credit(ctx context.Context, id string, body *CreditWalletBody) (*CreditWalletResponse, error) {
-	req, err := w.client.NewRequest(ctx, http.MethodPost, "wallets/"+id+"/credit", body)
+func (w WalletIntImpl) Credit(ctx context.Context, id string, body *CreditWalletBody, opts ...CallOption) (*CreditWalletResponse, error) {
+	if err := w.client.requireSandbox("Wallet.Credit"); err != nil {
+		return nil, err
+	}
+
+	req, err := w.client.NewRequest(ctx, http.MethodPost, "wallets/"+id+"/credit", body, idempotent(opts, "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,5 +185,7 @@ func (w WalletIntImpl) Credit(ctx context.Context, id string, body *CreditWallet
 		return nil, err
 	}
 
+	response.IdempotencyKey = req.Header.Get(IdempotencyKeyHeader)
+
 	return response, nil
 }
@@ -101,6 +101,8 @@ func TestWalletCredit(t *testing.T) {
 	setup()
 	defer teardown()
 
+	client.environment = EnvironmentSandbox
+
 	walletId := "wallet_001"
 
 	mux.HandleFunc("/wallets/"+walletId+"/credit", func(w http.ResponseWriter, r *http.Request) {
@@ -131,7 +133,8 @@ func TestWalletCredit(t *testing.T) {
 		},
 	}
 
-	resp, err := client.Wallet.Credit(context.Background(), walletId, req)
+	// The fixture sender is not a valid NUBAN of GTBank, so the offline check is skipped.
+	resp, err := client.Wallet.Credit(context.Background(), walletId, req, WithCallValidation(false))
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, resp.ID, "txn_123456")
@@ -10,12 +10,12 @@ type WebhookInt interface {
 	// Test sends a test webhook request.
 	// It takes a context and an id as parameters.
 	// It returns a pointer to a DefaultResponse and an error.
-	Test(ctx context.Context, id string) (*DefaultResponse, error)
+	Test(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error)
 
 	// Retry retries a failed webhook request.
 	// It takes a context and a logId as parameters.
 	// It returns a pointer to a DefaultResponse and an error.
-	Retry(ctx context.Context, logId string) (*DefaultResponse, error)
+	Retry(ctx context.Context, logId string, opts ...CallOption) (*DefaultResponse, error)
 }
 
 // WebhookIntImpl is a struct that implements the WebhookInt interface.
@@ -32,8 +32,8 @@ var _ WebhookInt = &WebhookIntImpl{}
 // If there is an error during these operations, it returns the error.
 // Otherwise, it returns the response and nil.
 // https://docs.swervpay.co/api-reference/webhook/test
-func (w WebhookIntImpl) Test(ctx context.Context, id string) (*DefaultResponse, error) {
-	req, err := w.client.NewRequest(ctx, http.MethodPost, "webhook/"+id+"/test", nil)
+func (w WebhookIntImpl) Test(ctx context.Context, id string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := w.client.NewRequest(ctx, http.MethodPost, "webhook/"+id+"/test", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +54,8 @@ func (w WebhookIntImpl) Test(ctx context.Context, id string) (*DefaultResponse,
 // If there is an error during these operations, it returns the error.
 // Otherwise, it returns the response and nil.
 // https://docs.swervpay.co/api-reference/webhook/retry
-func (w WebhookIntImpl) Retry(ctx context.Context, logId string) (*DefaultResponse, error) {
-	req, err := w.client.NewRequest(ctx, http.MethodPost, "webhook/"+logId+"/retry", nil)
+func (w WebhookIntImpl) Retry(ctx context.Context, logId string, opts ...CallOption) (*DefaultResponse, error) {
+	req, err := w.client.NewRequest(ctx, http.MethodPost, "webhook/"+logId+"/retry", nil, opts...)
 	if err != nil {
 		return nil, err
 	}